@@ -1 +1,64 @@
 package config
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GetString: 환경 변수 문자열 조회 (없으면 기본값)
+func GetString(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// GetInt: 환경 변수 정수 조회 (없거나 잘못된 값이면 기본값)
+func GetInt(key string, defaultValue int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		log.Printf("⚠️ Invalid int value for %s: %s, using default %d", key, value, defaultValue)
+		return defaultValue
+	}
+	return n
+}
+
+// GetDuration: 환경 변수 시간 조회 (예: "15m", "30s")
+func GetDuration(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		log.Printf("⚠️ Invalid duration value for %s: %s, using default %v", key, value, defaultValue)
+		return defaultValue
+	}
+	return d
+}
+
+// GetStringList: 콤마로 구분된 환경 변수 목록 조회
+func GetStringList(key string, defaultValue []string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	var list []string
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
@@ -7,16 +7,18 @@ import (
 )
 
 type RoomDetailResponse struct {
-	ID                  string             `bson:"id" json:"id"` // UUID 사용
-	Type                int                `bson:"type" json:"type"`
-	Status              int                `bson:"status" json:"status"`
-	Seq                 int                `bson:"seq" json:"seq"`
-	RoomName            string             `bson:"room_name" json:"room_name"`
-	Users               []commontype.Gamer `bson:"users" json:"users"`
-	CreatedAt           time.Time          `bson:"created_at" json:"created_at"`
-	FinishChatAt        time.Time          `bson:"finish_chat_at" json:"finish_chat_at"`
-	FinishFinalChoiceAt time.Time          `bson:"finish_final_choice_at" json:"finish_final_choice_at"`
-	ModifiedAt          time.Time          `bson:"modified_at" json:"modified_at"`
+	ID                  string                 `bson:"id" json:"id"` // UUID 사용
+	Type                int                    `bson:"type" json:"type"`
+	Status              int                    `bson:"status" json:"status"`
+	Seq                 int                    `bson:"seq" json:"seq"`
+	RoomName            string                 `bson:"room_name" json:"room_name"`
+	Users               []commontype.Gamer     `bson:"users" json:"users"`
+	CreatedAt           time.Time              `bson:"created_at" json:"created_at"`
+	FinishChatAt        time.Time              `bson:"finish_chat_at" json:"finish_chat_at"`
+	FinishFinalChoiceAt time.Time              `bson:"finish_final_choice_at" json:"finish_final_choice_at"`
+	RevealPolicy        int                    `bson:"reveal_policy" json:"reveal_policy"`
+	Timeline            []models.TimelineEntry `bson:"timeline" json:"timeline"`
+	ModifiedAt          time.Time              `bson:"modified_at" json:"modified_at"`
 }
 
 type RoomListResponse struct {
@@ -35,6 +37,52 @@ type ChatListResponse struct {
 	NextPage    int            `json:"nextPage,omitempty"`
 	HasNextPage bool           `json:"hasNextPage"`
 	TotalPages  int            `json:"totalPages"`
+	NextCursor  string         `json:"nextCursor,omitempty"` // before로 넘기면 더 오래된 메시지 조회
+	PrevCursor  string         `json:"prevCursor,omitempty"` // after로 넘기면 더 새로운 메시지 조회
+}
+
+// ChatSearchResponse - 채팅방 메시지 검색 결과
+type ChatSearchResponse struct {
+	Data        []ChatSearchHit `json:"data"`
+	HasNextPage bool            `json:"hasNextPage"`
+	NextCursor  string          `json:"nextCursor,omitempty"` // before로 넘기면 이어서 검색
+}
+
+// ChatSearchHit - 검색된 메시지와 앞뒤 메시지 (모두 최신순)
+// 메시지 ID를 /list/:id의 before/after 커서로 사용해 해당 위치로 이동
+type ChatSearchHit struct {
+	Message *models.Chat   `json:"message"`
+	Before  []*models.Chat `json:"before"` // 검색된 메시지보다 이전 메시지
+	After   []*models.Chat `json:"after"`  // 검색된 메시지보다 이후 메시지
+}
+
+// TranscriptMessage - 대화 내보내기(JSON)의 메시지 한 건, 사용자 ID 대신 캐릭터 이름 사용
+type TranscriptMessage struct {
+	MessageID string             `json:"message_id"`
+	Type      string             `json:"type"`
+	Sender    string             `json:"sender,omitempty"` // 시스템 메시지는 비어있음
+	Message   string             `json:"message"`
+	CreatedAt time.Time          `json:"created_at"`
+	EditedAt  *time.Time         `json:"edited_at,omitempty"`
+	Deleted   bool               `json:"deleted,omitempty"`
+	ImageURL  string             `json:"image_url,omitempty"`
+	ReplyTo   string             `json:"reply_to,omitempty"`
+	Balance   *TranscriptBalance `json:"balance,omitempty"` // 밸런스 게임 질문/결과 메시지
+}
+
+// TranscriptBalance - 대화 내보내기에 포함되는 밸런스 게임 질문과 결과
+type TranscriptBalance struct {
+	Title      string                  `json:"title"`
+	Red        string                  `json:"red"`
+	Blue       string                  `json:"blue"`
+	Votes      *models.Votes           `json:"votes,omitempty"`
+	WinnerTeam *int                    `json:"winner_team,omitempty"`
+	Sides      []TranscriptBalanceSide `json:"sides,omitempty"`
+}
+
+type TranscriptBalanceSide struct {
+	CharacterName string `json:"character_name"`
+	Choiced       int    `json:"choiced"` // -1: 미투표, 0: red, 1: blue
 }
 
 type LastMessage struct {
@@ -42,4 +90,13 @@ type LastMessage struct {
 	Message   string              `json:"message"`
 	GameInfo  commontype.GameInfo `json:"game_info"`
 	CreatedAt time.Time           `json:"created_at"`
+	EditedAt  *time.Time          `json:"edited_at,omitempty"`
+	Deleted   bool                `json:"deleted"`
+}
+
+// ChatMediaResponse - 채팅 이미지 업로드 응답
+type ChatMediaResponse struct {
+	models.ChatMedia
+	URL          string `json:"url"`
+	ThumbnailURL string `json:"thumbnail_url"`
 }
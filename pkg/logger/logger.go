@@ -55,6 +55,12 @@ const (
 
 	// 채팅방 관련 이벤트
 	LogEventRoomLeave
+
+	// 게임방 추가 이벤트 (기존 값 유지를 위해 뒤에 추가)
+	LogEventGameRoomExtend
+	LogEventFirstImpressionStart
+	LogEventFirstImpressionEnd
+	LogEventCoupleContactExchange
 )
 
 // LogEventType은 로그 이벤트 타입을 나타내는 정수입니다
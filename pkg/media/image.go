@@ -0,0 +1,117 @@
+package media
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+	"net/http"
+)
+
+// 업로드 가능한 이미지 형식과 저장 확장자
+var imageExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+}
+
+// 디코딩 전에 거르는 최대 픽셀 수 (압축 폭탄 방지, 디코딩 결과가 업로드당 수십 MB를 넘지 않도록 제한)
+const maxImagePixels = 16_000_000
+
+// 축소 시 결과 픽셀 하나마다 가로/세로로 읽는 원본 지점 수
+const resizeSamples = 4
+
+// Image - 검증을 통과한 업로드 이미지
+type Image struct {
+	ContentType string
+	Extension   string
+	Width       int
+	Height      int
+	Thumbnail   []byte // JPEG
+}
+
+// ProcessImage - 크기/형식 검증 후 긴 변 기준 thumbnailSize 썸네일 생성
+func ProcessImage(data []byte, maxBytes int64, thumbnailSize int) (*Image, error) {
+	if int64(len(data)) > maxBytes {
+		return nil, ErrTooLarge
+	}
+
+	// 확장자나 클라이언트 Content-Type이 아니라 실제 내용으로 형식 판별
+	contentType := http.DetectContentType(data)
+	ext, ok := imageExtensions[contentType]
+	if !ok {
+		return nil, ErrUnsupportedType
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedType, err)
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > maxImagePixels {
+		return nil, ErrTooLarge
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedType, err)
+	}
+
+	var thumbnail bytes.Buffer
+	err = jpeg.Encode(&thumbnail, resize(src, thumbnailSize), &jpeg.Options{Quality: 80})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode thumbnail: %w", err)
+	}
+
+	return &Image{
+		ContentType: contentType,
+		Extension:   ext,
+		Width:       cfg.Width,
+		Height:      cfg.Height,
+		Thumbnail:   thumbnail.Bytes(),
+	}, nil
+}
+
+// resize - 긴 변이 maxSize가 되도록 박스 평균으로 축소 (투명 영역은 흰색 배경)
+// 원본 크기의 중간 버퍼 없이 박스마다 최대 resizeSamples×resizeSamples 지점만 읽어 평균
+func resize(src image.Image, maxSize int) image.Image {
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	dstWidth, dstHeight := width, height
+	if width > maxSize || height > maxSize {
+		if width >= height {
+			dstWidth, dstHeight = maxSize, max(1, height*maxSize/width)
+		} else {
+			dstWidth, dstHeight = max(1, width*maxSize/height), maxSize
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+	for y := 0; y < dstHeight; y++ {
+		y0, y1 := y*height/dstHeight, max((y+1)*height/dstHeight, y*height/dstHeight+1)
+		stepY := max(1, (y1-y0)/resizeSamples)
+		for x := 0; x < dstWidth; x++ {
+			x0, x1 := x*width/dstWidth, max((x+1)*width/dstWidth, x*width/dstWidth+1)
+			stepX := max(1, (x1-x0)/resizeSamples)
+
+			var r, g, b, n uint32
+			for sy := y0; sy < y1; sy += stepY {
+				for sx := x0; sx < x1; sx += stepX {
+					// RGBA()는 알파가 곱해진 값이므로 흰 배경 합성은 (1 - 알파)만큼 더하면 됨
+					cr, cg, cb, ca := src.At(bounds.Min.X+sx, bounds.Min.Y+sy).RGBA()
+					r += cr + 0xffff - ca
+					g += cg + 0xffff - ca
+					b += cb + 0xffff - ca
+					n++
+				}
+			}
+
+			dst.SetRGBA(x, y, color.RGBA{R: uint8(r / n >> 8), G: uint8(g / n >> 8), B: uint8(b / n >> 8), A: 255})
+		}
+	}
+
+	return dst
+}
@@ -0,0 +1,101 @@
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+func TestResize(t *testing.T) {
+	tests := []struct {
+		name          string
+		width, height int
+		maxSize       int
+		wantW, wantH  int
+	}{
+		{"가로가 긴 이미지", 400, 100, 200, 200, 50},
+		{"세로가 긴 이미지", 90, 300, 150, 45, 150},
+		{"작은 이미지는 그대로", 64, 32, 200, 64, 32},
+		{"아주 얇은 이미지도 1픽셀 유지", 1000, 2, 100, 100, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := resize(image.NewRGBA(image.Rect(0, 0, tt.width, tt.height)), tt.maxSize).Bounds()
+			if got.Dx() != tt.wantW || got.Dy() != tt.wantH {
+				t.Errorf("resize(%dx%d, %d) = %dx%d, want %dx%d", tt.width, tt.height, tt.maxSize, got.Dx(), got.Dy(), tt.wantW, tt.wantH)
+			}
+		})
+	}
+}
+
+func TestResizeCompositesOnWhite(t *testing.T) {
+	// 왼쪽 절반은 불투명 빨강, 오른쪽 절반은 완전 투명
+	src := image.NewNRGBA(image.Rect(10, 10, 50, 30))
+	for y := 10; y < 30; y++ {
+		for x := 10; x < 30; x++ {
+			src.SetNRGBA(x, y, color.NRGBA{R: 255, A: 255})
+		}
+	}
+
+	dst := resize(src, 20).(*image.RGBA)
+	if got := dst.RGBAAt(2, 5); got != (color.RGBA{R: 255, A: 255}) {
+		t.Errorf("opaque pixel = %v, want red", got)
+	}
+	if got := dst.RGBAAt(17, 5); got != (color.RGBA{R: 255, G: 255, B: 255, A: 255}) {
+		t.Errorf("transparent pixel = %v, want white", got)
+	}
+}
+
+func TestProcessImage(t *testing.T) {
+	var encoded bytes.Buffer
+	if err := png.Encode(&encoded, image.NewGray(image.Rect(0, 0, 300, 150))); err != nil {
+		t.Fatal(err)
+	}
+
+	img, err := ProcessImage(encoded.Bytes(), 1<<20, 100)
+	if err != nil {
+		t.Fatalf("ProcessImage() error = %v", err)
+	}
+	if img.ContentType != "image/png" || img.Extension != ".png" || img.Width != 300 || img.Height != 150 {
+		t.Errorf("ProcessImage() = %s %s %dx%d, want image/png .png 300x150", img.ContentType, img.Extension, img.Width, img.Height)
+	}
+
+	thumbnail, err := jpeg.Decode(bytes.NewReader(img.Thumbnail))
+	if err != nil {
+		t.Fatalf("thumbnail is not a JPEG: %v", err)
+	}
+	if b := thumbnail.Bounds(); b.Dx() != 100 || b.Dy() != 50 {
+		t.Errorf("thumbnail = %dx%d, want 100x50", b.Dx(), b.Dy())
+	}
+
+	if _, err := ProcessImage(encoded.Bytes(), int64(encoded.Len()-1), 100); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("over byte limit error = %v, want ErrTooLarge", err)
+	}
+	if _, err := ProcessImage([]byte("plain text, not an image"), 1<<20, 100); !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("text upload error = %v, want ErrUnsupportedType", err)
+	}
+}
+
+// 헤더의 크기만 보고 디코딩 전에 거절
+func TestProcessImageRejectsHugeDimensions(t *testing.T) {
+	var encoded bytes.Buffer
+	if err := png.Encode(&encoded, image.NewGray(image.Rect(0, 0, 1, 1))); err != nil {
+		t.Fatal(err)
+	}
+
+	// IHDR의 가로/세로(바이트 16~23)를 20000x20000으로 바꾸고 청크 CRC 다시 계산
+	data := encoded.Bytes()
+	copy(data[16:24], []byte{0, 0, 0x4e, 0x20, 0, 0, 0x4e, 0x20})
+	binary.BigEndian.PutUint32(data[29:33], crc32.ChecksumIEEE(data[12:29]))
+
+	if _, err := ProcessImage(data, 1<<20, 100); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("20000x20000 header error = %v, want ErrTooLarge", err)
+	}
+}
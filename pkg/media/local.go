@@ -0,0 +1,86 @@
+package media
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LocalStore - 로컬 파일 시스템 저장소 (게이트웨이의 /app/images와 같은 방식)
+type LocalStore struct {
+	baseDir string
+}
+
+func NewLocalStore(baseDir string) (*LocalStore, error) {
+	if err := os.MkdirAll(baseDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create media dir %s: %w", baseDir, err)
+	}
+	return &LocalStore{baseDir: baseDir}, nil
+}
+
+func (s *LocalStore) Put(ctx context.Context, key, contentType string, data []byte) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	// 임시 파일에 쓴 뒤 rename 해서 읽는 쪽이 반쯤 쓰인 파일을 보지 않도록 함
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+func (s *LocalStore) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return file, err
+}
+
+func (s *LocalStore) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// path - 키를 baseDir 하위 경로로 변환 (디렉터리 탈출 방지)
+func (s *LocalStore) path(key string) (string, error) {
+	path := filepath.Join(s.baseDir, filepath.FromSlash(key))
+	if !strings.HasPrefix(path, filepath.Clean(s.baseDir)+string(os.PathSeparator)) {
+		return "", fmt.Errorf("invalid media key: %s", key)
+	}
+	return path, nil
+}
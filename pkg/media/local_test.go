@@ -0,0 +1,46 @@
+package media
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestLocalStore(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key := ObjectKey("room", "media", ".jpg")
+	if err := store.Put(ctx, key, "image/jpeg", []byte("data")); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	reader, err := store.Open(ctx, key)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	data, _ := io.ReadAll(reader)
+	reader.Close()
+	if string(data) != "data" {
+		t.Errorf("Open() read %q, want %q", data, "data")
+	}
+
+	if err := store.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := store.Open(ctx, key); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Open() after delete error = %v, want ErrNotFound", err)
+	}
+	// 없는 객체 삭제는 에러 아님
+	if err := store.Delete(ctx, key); err != nil {
+		t.Errorf("second Delete() error = %v", err)
+	}
+
+	if err := store.Put(ctx, "../escape.jpg", "image/jpeg", []byte("x")); err == nil {
+		t.Error("Put() outside the base dir succeeded")
+	}
+}
@@ -0,0 +1,66 @@
+package media
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+
+	"solo/pkg/config"
+)
+
+var (
+	ErrNotFound        = errors.New("media object not found")
+	ErrTooLarge        = errors.New("media file is too large")
+	ErrUnsupportedType = errors.New("unsupported media type")
+)
+
+// Store - 채팅 미디어 원본/썸네일 저장소
+type Store interface {
+	// Put - key 위치에 객체 저장 (이미 있으면 덮어씀)
+	Put(ctx context.Context, key, contentType string, data []byte) error
+	// Open - 객체 읽기, 없으면 ErrNotFound
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete - 객체 삭제, 없어도 에러 아님
+	Delete(ctx context.Context, key string) error
+}
+
+// NewStoreFromConfig: MEDIA_STORE 환경 변수에 따라 저장소 생성 (local, s3)
+func NewStoreFromConfig() (Store, error) {
+	switch kind := config.GetString("MEDIA_STORE", "local"); kind {
+	case "local":
+		dir := config.GetString("MEDIA_LOCAL_DIR", "/app/media")
+		log.Printf("🗂️ Media store: local (%s)", dir)
+		return NewLocalStore(dir)
+	case "s3":
+		store, err := NewS3Store(S3Config{
+			Endpoint:  config.GetString("MEDIA_S3_ENDPOINT", ""),
+			Region:    config.GetString("MEDIA_S3_REGION", "us-east-1"),
+			Bucket:    config.GetString("MEDIA_S3_BUCKET", ""),
+			AccessKey: config.GetString("MEDIA_S3_ACCESS_KEY", ""),
+			SecretKey: config.GetString("MEDIA_S3_SECRET_KEY", ""),
+		})
+		if err != nil {
+			return nil, err
+		}
+		log.Printf("🗂️ Media store: s3 (%s)", store.bucketURL())
+		return store, nil
+	default:
+		return nil, fmt.Errorf("unknown MEDIA_STORE: %s", kind)
+	}
+}
+
+// ObjectKey - 방별 미디어 객체 키
+func ObjectKey(roomID, mediaID, ext string) string {
+	return fmt.Sprintf("rooms/%s/%s%s", roomID, mediaID, ext)
+}
+
+// URL - 채팅 서비스의 미디어 조회 경로 (게이트웨이 기준)
+func URL(roomID, mediaID string, thumbnail bool) string {
+	url := fmt.Sprintf("/chat/media/%s/%s", roomID, mediaID)
+	if thumbnail {
+		url += "?thumb=true"
+	}
+	return url
+}
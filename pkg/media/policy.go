@@ -0,0 +1,26 @@
+package media
+
+import (
+	"solo/pkg/config"
+	"solo/pkg/types/commontype"
+)
+
+// ImageAllowedInRoom - 방 종류/상태별 이미지 전송 허용 여부
+// 커플방은 종료 전까지 허용, 게임방은 GAME_ROOM_IMAGE_PHASE(chat, final_choice, end) 이후부터 허용 (기본 비허용)
+func ImageAllowedInRoom(roomType, roomStatus int) bool {
+	switch roomType {
+	case commontype.MATCH_COUPLE:
+		return roomStatus < commontype.RoomStatusGameEnd
+	case commontype.MATCH_GAME:
+		minStatus, ok := gameRoomImageStatus[config.GetString("GAME_ROOM_IMAGE_PHASE", commontype.GameRoomImagePhase)]
+		return ok && roomStatus >= minStatus
+	default:
+		return false
+	}
+}
+
+var gameRoomImageStatus = map[string]int{
+	commontype.RoomPhaseChat:        commontype.RoomStatusGameIng,
+	commontype.RoomPhaseFinalChoice: commontype.RoomStatusChoiceIng,
+	commontype.RoomPhaseEnd:         commontype.RoomStatusGameEnd,
+}
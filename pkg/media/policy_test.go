@@ -0,0 +1,47 @@
+package media
+
+import (
+	"testing"
+
+	"solo/pkg/types/commontype"
+)
+
+func TestImageAllowedInRoom(t *testing.T) {
+	t.Run("커플방은 종료 전까지 허용", func(t *testing.T) {
+		if !ImageAllowedInRoom(commontype.MATCH_COUPLE, commontype.RoomStatusGameIng) {
+			t.Error("open couple room rejected images")
+		}
+		if ImageAllowedInRoom(commontype.MATCH_COUPLE, commontype.RoomStatusGameEnd) {
+			t.Error("closed couple room allowed images")
+		}
+	})
+
+	t.Run("게임방은 기본 비허용", func(t *testing.T) {
+		t.Setenv("GAME_ROOM_IMAGE_PHASE", "")
+		for status := commontype.RoomStatusGameStart; status <= commontype.RoomStatusGameEnd; status++ {
+			if ImageAllowedInRoom(commontype.MATCH_GAME, status) {
+				t.Errorf("game room status %d allowed images without GAME_ROOM_IMAGE_PHASE", status)
+			}
+		}
+	})
+
+	t.Run("게임방은 설정한 구간부터 허용", func(t *testing.T) {
+		t.Setenv("GAME_ROOM_IMAGE_PHASE", commontype.RoomPhaseFinalChoice)
+		if ImageAllowedInRoom(commontype.MATCH_GAME, commontype.RoomStatusGameIng) {
+			t.Error("chat phase allowed images with final_choice setting")
+		}
+		if !ImageAllowedInRoom(commontype.MATCH_GAME, commontype.RoomStatusChoiceIng) {
+			t.Error("final choice phase rejected images")
+		}
+		if !ImageAllowedInRoom(commontype.MATCH_GAME, commontype.RoomStatusGameEnd) {
+			t.Error("ended game room rejected images")
+		}
+	})
+
+	t.Run("알 수 없는 구간 설정은 비허용", func(t *testing.T) {
+		t.Setenv("GAME_ROOM_IMAGE_PHASE", "always")
+		if ImageAllowedInRoom(commontype.MATCH_GAME, commontype.RoomStatusGameEnd) {
+			t.Error("unknown phase setting allowed images")
+		}
+	})
+}
@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"net/http"
+	"solo/pkg/config"
+
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+)
+
+// AdminOnly - ADMIN_USER_IDS(콤마 구분)에 등록된 사용자만 접근 허용
+func AdminOnly() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			// X-User-ID 헤더에서 유저 ID 가져오기
+			userID := c.Request().Header.Get("X-User-ID")
+			if userID == "" {
+				return echo.NewHTTPError(http.StatusUnauthorized, "User ID is required")
+			}
+
+			if !lo.Contains(config.GetStringList("ADMIN_USER_IDS", nil), userID) {
+				return echo.NewHTTPError(http.StatusForbidden, "Admin access required")
+			}
+
+			return next(c)
+		}
+	}
+}
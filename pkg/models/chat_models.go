@@ -15,6 +15,82 @@ type Chat struct {
 	UnreadCount   int                `bson:"unread_count" json:"unread_count"`
 	CreatedAt     time.Time          `bson:"created_at" json:"created_at"`
 	BalanceFormID primitive.ObjectID `bson:"balance_form_id,omitempty" json:"balance_form_id,omitempty"`
+	BalanceResult *BalanceFormResult `bson:"balance_result,omitempty" json:"balance_result,omitempty"` // form_result 메시지의 최종 집계
+	RecipientID   int                `bson:"recipient_id,omitempty" json:"recipient_id,omitempty"`     // 귓속말 수신자
+	VisibleTo     []int              `bson:"visible_to,omitempty" json:"visible_to,omitempty"`         // 비어있으면 전체 공개, 귓속말은 발신자/수신자
+	Media         *MediaRef          `bson:"media,omitempty" json:"media,omitempty"`                   // 이미지 메시지의 미디어
+	EditedAt      *time.Time         `bson:"edited_at,omitempty" json:"edited_at,omitempty"`           // 마지막 수정 시각
+	EditHistory   []ChatEdit         `bson:"edit_history,omitempty" json:"-"`                          // 수정 전 메시지 기록
+	DeletedAt     *time.Time         `bson:"deleted_at,omitempty" json:"deleted_at,omitempty"`         // 보내기 취소 시각 (내용은 비워짐)
+	Unsent        *UnsentContent     `bson:"unsent,omitempty" json:"-"`                                // 보내기 취소 전 원본 (보관용, 응답에는 포함하지 않음)
+	ReplyTo       primitive.ObjectID `bson:"reply_to,omitempty" json:"reply_to,omitempty"`             // 답장 대상 메시지
+	ReplyPreview  *ReplyPreview      `bson:"-" json:"reply_preview,omitempty"`                         // 조회 시 답장 대상으로 생성
+	Reactions     []ReactionSummary  `bson:"-" json:"reactions,omitempty"`                             // 조회 시 message_reactions에서 집계
+	SearchTokens  []string           `bson:"search_tokens,omitempty" json:"-"`                         // 검색용 토큰 (단어 + 한글 n-gram)
+}
+
+// ReplyPreview - 답장 메시지에 함께 보여주는 인용 미리보기
+type ReplyPreview struct {
+	MessageID     primitive.ObjectID `json:"message_id"`
+	Type          string             `json:"type"`
+	SenderID      int                `json:"sender_id"`
+	CharacterName string             `json:"character_name"`
+	Snippet       string             `json:"snippet"`
+	Deleted       bool               `json:"deleted"` // 인용된 메시지가 보내기 취소되었거나 남아있지 않음
+}
+
+// ChatMedia - 업로드된 채팅 미디어 (chat_media 컬렉션)
+type ChatMedia struct {
+	ID           primitive.ObjectID `bson:"_id,omitempty" json:"media_id"`
+	RoomID       string             `bson:"room_id" json:"room_id"`
+	UploaderID   int                `bson:"uploader_id" json:"uploader_id"`
+	ContentType  string             `bson:"content_type" json:"content_type"`
+	Size         int64              `bson:"size" json:"size"`
+	Width        int                `bson:"width" json:"width"`
+	Height       int                `bson:"height" json:"height"`
+	ObjectKey    string             `bson:"object_key" json:"-"`
+	ThumbnailKey string             `bson:"thumbnail_key" json:"-"`
+	MessageID    primitive.ObjectID `bson:"message_id,omitempty" json:"-"` // 이 미디어를 보낸 메시지 (한 메시지에서만 사용)
+	CreatedAt    time.Time          `bson:"created_at" json:"created_at"`
+}
+
+// MediaRef - 메시지에 포함되는 미디어 참조
+type MediaRef struct {
+	MediaID      primitive.ObjectID `bson:"media_id" json:"media_id"`
+	ContentType  string             `bson:"content_type" json:"content_type"`
+	Width        int                `bson:"width" json:"width"`
+	Height       int                `bson:"height" json:"height"`
+	URL          string             `bson:"url" json:"url"`
+	ThumbnailURL string             `bson:"thumbnail_url" json:"thumbnail_url"`
+}
+
+// ChatEdit - 메시지 수정 이력
+type ChatEdit struct {
+	Message  string    `bson:"message" json:"message"`
+	EditedAt time.Time `bson:"edited_at" json:"edited_at"`
+}
+
+// UnsentContent - 보내기 취소된 메시지의 원본 (신고/분쟁 처리를 위해 보관 데이터에만 남김)
+type UnsentContent struct {
+	Message     string     `bson:"message" json:"message"`
+	EditHistory []ChatEdit `bson:"edit_history,omitempty" json:"edit_history,omitempty"`
+	Media       *MediaRef  `bson:"media,omitempty" json:"media,omitempty"`
+}
+
+// MessageReaction - 메시지 리액션 (message_reactions 컬렉션)
+type MessageReaction struct {
+	MessageID primitive.ObjectID `bson:"message_id" json:"message_id"`
+	RoomID    string             `bson:"room_id" json:"room_id"`
+	UserID    int                `bson:"user_id" json:"user_id"`
+	Reaction  string             `bson:"reaction" json:"reaction"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+}
+
+// ReactionSummary - 메시지별 리액션 집계
+type ReactionSummary struct {
+	Reaction    string `json:"reaction"`
+	Count       int    `json:"count"`
+	ReactedByMe bool   `json:"reacted_by_me"`
 }
 
 type ChatReader struct {
@@ -25,21 +101,69 @@ type ChatReader struct {
 }
 
 type ChatRoom struct {
-	ID                  string      `bson:"id" json:"id"` // UUID 사용
-	Name                string      `bson:"name" json:"name"`
-	Type                int         `bson:"type" json:"type"`
-	Status              int         `bson:"status" json:"status"`
-	UserIDs             []int       `bson:"user_ids" json:"user_ids"`
-	Gamers              []GamerInfo `bson:"gamers" json:"gamers"` // 사용자별 캐릭터 정보
-	Seq                 int64       `bson:"seq" json:"seq"`       // 자동 증가 필드
-	CreatedAt           time.Time   `bson:"created_at" json:"created_at"`
-	FinishChatAt        time.Time   `bson:"finish_chat_at" json:"finish_chat_at"`
-	FinishFinalChoiceAt time.Time   `bson:"finish_final_choice_at" json:"finish_final_choice_at"`
-	ModifiedAt          time.Time   `bson:"modified_at" json:"modified_at"`
+	ID                  string          `bson:"id" json:"id"` // UUID 사용
+	Name                string          `bson:"name" json:"name"`
+	Type                int             `bson:"type" json:"type"`
+	Status              int             `bson:"status" json:"status"`
+	UserIDs             []int           `bson:"user_ids" json:"user_ids"`
+	Gamers              []GamerInfo     `bson:"gamers" json:"gamers"` // 사용자별 캐릭터 정보
+	Seq                 int64           `bson:"seq" json:"seq"`       // 자동 증가 필드
+	CreatedAt           time.Time       `bson:"created_at" json:"created_at"`
+	FinishChatAt        time.Time       `bson:"finish_chat_at" json:"finish_chat_at"`
+	FinishFinalChoiceAt time.Time       `bson:"finish_final_choice_at" json:"finish_final_choice_at"`
+	RevealPolicy        int             `bson:"reveal_policy" json:"reveal_policy"` // 최종 선택 결과 공개 정책
+	Timeline            []TimelineEntry `bson:"timeline" json:"timeline"`           // 방 활동 예정표
+	ExtendCount         int             `bson:"extend_count" json:"extend_count"`   // 대화 시간 연장 횟수
+	ModifiedAt          time.Time       `bson:"modified_at" json:"modified_at"`
+}
+
+// ChatRoomSummary - 채팅방 목록용 방 정보 (마지막 메시지, 안 읽은 메시지 수, 내 캐릭터 정보)
+type ChatRoomSummary struct {
+	ChatRoom    `bson:",inline"`
+	LastMessage *Chat      `bson:"last_message,omitempty"` // 메시지가 없으면 nil
+	UnreadCount int        `bson:"unread_count"`
+	Gamer       *GamerInfo `bson:"gamer,omitempty"` // 방에 캐릭터 정보가 없으면 nil
+}
+
+// CleanupJob - 방 종료 후 예약된 데이터 정리 작업
+type CleanupJob struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	RoomID      string             `bson:"room_id" json:"room_id"`
+	RunAt       time.Time          `bson:"run_at" json:"run_at"`
+	Status      string             `bson:"status" json:"status"`                                 // pending, running, done, cancelled, failed
+	Steps       []string           `bson:"steps" json:"steps"`                                   // 완료된 정리 단계 (재시도 시 건너뜀)
+	Attempts    int                `bson:"attempts" json:"attempts"`                             // 실행 시도 횟수
+	LastError   string             `bson:"last_error,omitempty" json:"last_error,omitempty"`     // 마지막 실패 사유
+	LockedUntil *time.Time         `bson:"locked_until,omitempty" json:"locked_until,omitempty"` // 실행 중인 워커의 점유 만료 시각
+	CreatedAt   time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt   time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// RoomArchive - 정리 전에 보관한 방 데이터 (방, 메시지, 밸런스 게임 폼/투표/댓글을 gzip 압축한 JSON)
+type RoomArchive struct {
+	ID           primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	RoomID       string             `bson:"room_id" json:"room_id"`
+	RoomSeq      int64              `bson:"room_seq" json:"room_seq"`
+	RoomType     int                `bson:"room_type" json:"room_type"`
+	UserIDs      []int              `bson:"user_ids" json:"user_ids"`
+	MessageCount int                `bson:"message_count" json:"message_count"`
+	Size         int                `bson:"size" json:"size"`                                 // 압축된 크기 (bytes)
+	Data         []byte             `bson:"data,omitempty" json:"-"`                          // 문서에 직접 보관한 압축 데이터
+	ObjectKey    string             `bson:"object_key,omitempty" json:"object_key,omitempty"` // 파일로 보관한 경우 미디어 저장소 키
+	MediaKeys    []string           `bson:"media_keys,omitempty" json:"media_keys,omitempty"` // archives/ 아래로 복사한 이미지 객체 키
+	ArchivedAt   time.Time          `bson:"archived_at" json:"archived_at"`
+	ExpireAt     time.Time          `bson:"expire_at" json:"expire_at"`
+}
+
+// TimelineEntry - 방에 예약된 활동
+type TimelineEntry struct {
+	Activity string    `bson:"activity" json:"activity"` // 활동 이름 (balance_game 등)
+	StartAt  time.Time `bson:"start_at" json:"start_at"` // 시작 예정 시각
 }
 
 type GamerInfo struct {
 	UserID             int    `bson:"user_id" json:"user_id"`                             // 사용자 ID
+	Gender             int    `bson:"gender" json:"gender"`                               // 성별 (0: 남성, 1: 여성)
 	CharacterID        int    `bson:"character_id" json:"character_id"`                   // 캐릭터 식별자 (0 ~ 5)
 	CharacterName      string `bson:"character_avatar_name" json:"character_avatar_name"` // 캐릭터 이름
 	CharacterAvatarURL string `bson:"character_avatar_url" json:"character_avatar_url"`   // 캐릭터 아바타 이미지 URL
@@ -0,0 +1,94 @@
+package models
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 보내기 취소된 메시지의 원본은 저장(보관)에는 남고 API 응답에는 나가지 않아야 함
+func TestUnsentChatHidesOriginal(t *testing.T) {
+	deletedAt := time.Now()
+	chat := Chat{
+		MessageId: primitive.NewObjectID(),
+		Type:      "chat",
+		RoomID:    "room",
+		SenderID:  1,
+		DeletedAt: &deletedAt,
+		Unsent: &UnsentContent{
+			Message:     "지울 메시지",
+			EditHistory: []ChatEdit{{Message: "처음 메시지", EditedAt: deletedAt}},
+		},
+	}
+
+	body, err := json.Marshal(chat)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, hidden := range []string{"지울 메시지", "처음 메시지", "unsent", "edit_history"} {
+		if strings.Contains(string(body), hidden) {
+			t.Errorf("JSON response contains %q: %s", hidden, body)
+		}
+	}
+
+	raw, err := bson.Marshal(chat)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var stored Chat
+	if err := bson.Unmarshal(raw, &stored); err != nil {
+		t.Fatal(err)
+	}
+	if stored.Unsent == nil || stored.Unsent.Message != "지울 메시지" || len(stored.Unsent.EditHistory) != 1 {
+		t.Errorf("stored unsent = %+v, want original message and history", stored.Unsent)
+	}
+}
+
+// 채팅방 목록 집계 결과: 방 필드는 inline, 메시지나 캐릭터 정보가 없는 방은 nil로 디코딩
+func TestChatRoomSummaryDecode(t *testing.T) {
+	createdAt := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	full, err := bson.Marshal(bson.M{
+		"id":           "room-1",
+		"name":         "방",
+		"type":         1,
+		"user_ids":     bson.A{1, 2},
+		"gamers":       bson.A{bson.M{"user_id": 1, "character_avatar_name": "호랑이"}},
+		"last_message": bson.M{"_id": primitive.NewObjectID(), "sender_id": 2, "message": "안녕", "created_at": createdAt},
+		"unread_count": 3,
+		"gamer":        bson.M{"user_id": 1, "character_avatar_name": "호랑이"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var summary ChatRoomSummary
+	if err := bson.Unmarshal(full, &summary); err != nil {
+		t.Fatalf("decode full summary: %v", err)
+	}
+	if summary.ID != "room-1" || summary.Name != "방" || len(summary.UserIDs) != 2 {
+		t.Errorf("room fields = %+v, want inline room", summary.ChatRoom)
+	}
+	if summary.LastMessage == nil || summary.LastMessage.Message != "안녕" || !summary.LastMessage.CreatedAt.Equal(createdAt) {
+		t.Errorf("last message = %+v, want 안녕 at %v", summary.LastMessage, createdAt)
+	}
+	if summary.UnreadCount != 3 || summary.Gamer == nil || summary.Gamer.CharacterName != "호랑이" {
+		t.Errorf("unread=%d gamer=%+v, want 3 and 호랑이", summary.UnreadCount, summary.Gamer)
+	}
+
+	empty, err := bson.Marshal(bson.M{"id": "room-2", "unread_count": 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var bare ChatRoomSummary
+	if err := bson.Unmarshal(empty, &bare); err != nil {
+		t.Fatalf("decode empty summary: %v", err)
+	}
+	if bare.LastMessage != nil || bare.Gamer != nil {
+		t.Errorf("empty room decoded last message %+v, gamer %+v, want nil", bare.LastMessage, bare.Gamer)
+	}
+}
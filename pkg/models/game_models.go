@@ -7,11 +7,13 @@ import (
 )
 
 type BalanceGameForm struct {
-	ID       primitive.ObjectID   `bson:"_id,omitempty" json:"id"`
-	RoomID   string               `bson:"room_id" json:"room_id"`
-	Question Question             `bson:"question" json:"question"`
-	Votes    Votes                `bson:"votes" json:"votes"`
-	Comments []BalanceFormComment `bson:"comments" json:"comments"`
+	ID         primitive.ObjectID   `bson:"_id,omitempty" json:"id"`
+	RoomID     string               `bson:"room_id" json:"room_id"`
+	Question   Question             `bson:"question" json:"question"`
+	Votes      Votes                `bson:"votes" json:"votes"`
+	Comments   []BalanceFormComment `bson:"comments" json:"comments"`
+	FinishedAt *time.Time           `bson:"finished_at,omitempty" json:"finished_at,omitempty"` // 종료 후에는 투표 불가
+	ResultAt   *time.Time           `bson:"result_at,omitempty" json:"-"`                       // 결과 메시지 전송 선점 시각
 }
 
 type Question struct {
@@ -42,16 +44,45 @@ type BalanceFormVote struct {
 
 type BalanceGameResult struct {
 	GameID     primitive.ObjectID `bson:"balance_game_id" json:"balance_game_id"` // 밸런스 게임 ID
-	WinnerTeam int                `bson:"winner_team" json:"winner_team"`         // 승리 팀 (0: red, 1: blue)
+	WinnerTeam int                `bson:"winner_team" json:"winner_team"`         // 승리 팀 (-1: 투표 없음, 0: red, 1: blue, 2: 동점)
+	RedCount   int                `bson:"red_cnt" json:"red_cnt"`
+	BlueCount  int                `bson:"blue_cnt" json:"blue_cnt"`
+}
+
+// BalanceFormResult - form_result 메시지에 포함되는 최종 집계
+type BalanceFormResult struct {
+	Votes      Votes             `bson:"votes" json:"votes"`
+	WinnerTeam int               `bson:"winner_team" json:"winner_team"`
+	Sides      []BalanceFormSide `bson:"sides" json:"sides"`
+}
+
+// BalanceFormSide - 참가자별 선택
+type BalanceFormSide struct {
+	UserID        int    `bson:"user_id" json:"user_id"`
+	CharacterName string `bson:"character_name" json:"character_name"`
+	Choiced       int    `bson:"choiced" json:"choiced"` // -1: 미투표, 0: red, 1: blue
 }
 
 type MatchHistory struct {
-	ID             primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
-	RoomSeq        int                 `bson:"room_seq" json:"room_seq"`
-	UserIDs        []int               `bson:"user_ids" json:"user_ids"`
-	BalanceResults []BalanceGameResult `bson:"balance_results" json:"balance_results"` // 최종 선택 완료 후 업데이트
-	FinalMatch     []string            `bson:"final_match" json:"final_match"`         // 최종 선택 완료 후 업데이트
-	CreatedAt      time.Time           `bson:"created_at" json:"created_at"`
+	ID               primitive.ObjectID      `bson:"_id,omitempty" json:"id"`
+	RoomSeq          int                     `bson:"room_seq" json:"room_seq"`
+	UserIDs          []int                   `bson:"user_ids" json:"user_ids"`
+	BalanceResults   []BalanceGameResult     `bson:"balance_results" json:"balance_results"`                         // 최종 선택 완료 후 업데이트
+	FinalMatch       []string                `bson:"final_match" json:"final_match"`                                 // 최종 선택 완료 후 업데이트
+	FirstImpressions []FirstImpressionResult `bson:"first_impressions,omitempty" json:"first_impressions,omitempty"` // 첫인상 선택 라운드 종료 후 업데이트
+	CreatedAt        time.Time               `bson:"created_at" json:"created_at"`
+}
+
+// FirstImpressionResult - 첫인상 선택 라운드 결과 (분석용, 최종 선택과 별도 보관)
+type FirstImpressionResult struct {
+	RoundID  string                `bson:"round_id" json:"round_id"`
+	Picks    []FirstImpressionPick `bson:"picks" json:"picks"`
+	FinishAt time.Time             `bson:"finish_at" json:"finish_at"`
+}
+
+type FirstImpressionPick struct {
+	UserID         int `bson:"user_id" json:"user_id"`
+	SelectedUserID int `bson:"selected_user_id" json:"selected_user_id"`
 }
 
 type BalanceGame struct {
@@ -35,6 +35,9 @@ const (
 	RoutingKeyRoomTimeout        = "room.timeout"
 	RoutingKeyChatLatest         = "chat.latest"
 	RoutingKeyVoteCommentChat    = "vote.comment.chat"
+	RoutingKeyRoomRemainTime     = "room.remain.time"
+	RoutingKeyCoupleRoomClose    = "couple.room.close"
+	RoutingKeyChatMediaDelete    = "chat.media.delete"
 )
 
 // Event Types
@@ -50,4 +53,6 @@ const (
 	EventTypeRoomRemainTime     = "room.remain.time"
 	EventTypeFinalChoiceTimeout = "final.choice.timeout"
 	EventTypeVoteCommentChat    = "vote.comment.chat"
+	EventTypeCoupleRoomClose    = "couple.room.close"
+	EventTypeChatMediaDelete    = "chat.media.delete"
 )
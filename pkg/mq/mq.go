@@ -3,8 +3,10 @@ package mq
 import (
 	"encoding/json"
 	"fmt"
+	"hash/fnv"
 	"log"
 	"os"
+	"solo/pkg/config"
 	eventtypes "solo/pkg/types/eventtype"
 
 	amqp "github.com/rabbitmq/amqp091-go"
@@ -103,12 +105,49 @@ func (mq *RabbitMQ) PublishMessage(exchange, routingKey string, body []byte) err
 	)
 }
 
+// DispatchMode: 수신한 메시지를 핸들러로 전달하는 방식
+type DispatchMode int
+
+const (
+	DispatchConcurrent DispatchMode = iota // 메시지마다 고루틴 실행 (순서 보장 없음)
+	// DispatchPerRoom: 방 ID 기준으로 워커 분배, 같은 방은 순서대로 처리
+	// 순서는 한 프로세스(파드) 안에서만 보장됨. 여러 파드가 같은 큐를 경쟁 소비하면
+	// 같은 방의 이벤트가 파드 사이에서 섞일 수 있으므로 파드 간 순서가 필요한 처리는 Redis 선점 등으로 보호해야 함
+	DispatchPerRoom
+)
+
+const (
+	defaultRoomWorkerCount     = 16
+	defaultRoomWorkerQueueSize = 64 // 워커별 대기열 크기, 가득 차면 수신 루프가 기다리며 prefetch 한도로 브로커 전송이 멈춤
+)
+
 // ConsumeMessages: 이벤트 타입별 핸들러 등록 및 실행
 func (mq *RabbitMQ) ConsumeMessages(queueName string, handlers EventHandlerMap) error {
+	return mq.ConsumeMessagesWithMode(queueName, handlers, DispatchConcurrent)
+}
+
+// ConsumeMessagesWithMode: 전달 방식을 지정하여 메시지 소비
+// DispatchPerRoom은 처리가 끝난 메시지만 ack하고 prefetch로 미처리 메시지 수를 제한해 워커가 밀리면 브로커 전송을 늦춤
+func (mq *RabbitMQ) ConsumeMessagesWithMode(queueName string, handlers EventHandlerMap, mode DispatchMode) error {
+	autoAck := true
+	dispatch := dispatchConcurrent
+	if mode == DispatchPerRoom {
+		workerCount := max(1, config.GetInt("MQ_ROOM_WORKER_COUNT", defaultRoomWorkerCount))
+		queueSize := max(1, config.GetInt("MQ_ROOM_WORKER_QUEUE_SIZE", defaultRoomWorkerQueueSize))
+
+		// 미처리 메시지를 워커 대기열 전체 크기로 제한
+		if err := mq.channel.Qos(workerCount*queueSize, 0, false); err != nil {
+			return err
+		}
+
+		autoAck = false
+		dispatch = newRoomDispatcher(workerCount, queueSize).dispatch
+	}
+
 	msgs, err := mq.channel.Consume(
 		queueName, // queue name
 		"",        // consumer
-		true,      // autoAck
+		autoAck,   // autoAck
 		false,     // exclusive
 		false,     // noLocal
 		false,     // noWait
@@ -121,17 +160,28 @@ func (mq *RabbitMQ) ConsumeMessages(queueName string, handlers EventHandlerMap)
 	// 메시지 처리 루프
 	go func() {
 		for msg := range msgs {
+			ack := func() {}
+			if !autoAck {
+				ack = func() {
+					if err := msg.Ack(false); err != nil {
+						log.Printf("❌ Failed to ack message: %v", err)
+					}
+				}
+			}
+
 			var eventPayload eventtypes.EventPayload
 			if err := json.Unmarshal(msg.Body, &eventPayload); err != nil {
 				log.Printf("❌ Failed to unmarshal EventPayload: %v", err)
+				ack()
 				continue
 			}
 
 			// EventType에 맞는 핸들러 실행
 			if handler, exists := handlers[eventPayload.EventType]; exists {
-				go handler(eventPayload.Data)
+				dispatch(handler, eventPayload.Data, ack)
 			} else {
 				log.Printf("⚠️ No handler found for event type: %s", eventPayload.EventType)
+				ack()
 			}
 		}
 	}()
@@ -139,3 +189,69 @@ func (mq *RabbitMQ) ConsumeMessages(queueName string, handlers EventHandlerMap)
 	log.Printf("✅ Listening on queue: %s", queueName)
 	return nil
 }
+
+func dispatchConcurrent(handler func(json.RawMessage), data json.RawMessage, done func()) {
+	go func() {
+		defer done()
+		handler(data)
+	}()
+}
+
+type roomTask struct {
+	handler func(json.RawMessage)
+	data    json.RawMessage
+	done    func()
+}
+
+// roomDispatcher: 방 ID 해시로 워커를 고르므로 같은 방의 이벤트는 항상 같은 워커에서 순서대로 실행
+type roomDispatcher struct {
+	workers []chan roomTask
+}
+
+func newRoomDispatcher(workerCount, queueSize int) *roomDispatcher {
+	d := &roomDispatcher{workers: make([]chan roomTask, workerCount)}
+	for i := range d.workers {
+		d.workers[i] = make(chan roomTask, queueSize)
+		go runRoomWorker(d.workers[i])
+	}
+
+	log.Printf("✅ Room ordered dispatch with %d workers (queue size %d)", workerCount, queueSize)
+	return d
+}
+
+// dispatch: 워커 대기열이 가득 차면 자리가 날 때까지 기다림 (backpressure)
+func (d *roomDispatcher) dispatch(handler func(json.RawMessage), data json.RawMessage, done func()) {
+	roomID := extractRoomID(data)
+	if roomID == "" {
+		// 방과 무관한 이벤트는 기존처럼 병렬 실행
+		dispatchConcurrent(handler, data, done)
+		return
+	}
+
+	hash := fnv.New32a()
+	hash.Write([]byte(roomID))
+	d.workers[hash.Sum32()%uint32(len(d.workers))] <- roomTask{handler: handler, data: data, done: done}
+}
+
+func runRoomWorker(tasks <-chan roomTask) {
+	for task := range tasks {
+		task.handler(task.data)
+		task.done()
+	}
+}
+
+// extractRoomID: 이벤트 데이터의 room_id (방 생성 이벤트는 id)
+func extractRoomID(data json.RawMessage) string {
+	var keys struct {
+		RoomID string `json:"room_id"`
+		ID     string `json:"id"`
+	}
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return ""
+	}
+
+	if keys.RoomID != "" {
+		return keys.RoomID
+	}
+	return keys.ID
+}
@@ -0,0 +1,74 @@
+package mq
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestExtractRoomID(t *testing.T) {
+	tests := []struct {
+		data string
+		want string
+	}{
+		{`{"room_id":"room-1","message":"hi"}`, "room-1"},
+		{`{"id":"room-2","user_ids":[1,2]}`, "room-2"},
+		{`{"room_id":"room-3","id":"other"}`, "room-3"},
+		{`{"user_id":1}`, ""},
+		{`not json`, ""},
+	}
+
+	for _, tt := range tests {
+		if got := extractRoomID(json.RawMessage(tt.data)); got != tt.want {
+			t.Errorf("extractRoomID(%s) = %q, want %q", tt.data, got, tt.want)
+		}
+	}
+}
+
+// 같은 방 이벤트는 들어온 순서대로 처리되고, 처리가 끝난 뒤에 ack 됨
+func TestRoomDispatcherKeepsRoomOrder(t *testing.T) {
+	d := newRoomDispatcher(4, 2)
+
+	var (
+		mu      sync.Mutex
+		handled = map[string][]int{}
+		wg      sync.WaitGroup
+	)
+
+	const perRoom = 50
+	rooms := []string{"room-a", "room-b", "room-c"}
+	for seq := 0; seq < perRoom; seq++ {
+		for _, roomID := range rooms {
+			roomID, seq := roomID, seq
+			data := json.RawMessage(fmt.Sprintf(`{"room_id":%q,"seq":%d}`, roomID, seq))
+
+			wg.Add(1)
+			acked := false
+			d.dispatch(func(json.RawMessage) {
+				mu.Lock()
+				defer mu.Unlock()
+				if acked {
+					t.Errorf("%s #%d acked before handling", roomID, seq)
+				}
+				handled[roomID] = append(handled[roomID], seq)
+			}, data, func() {
+				acked = true
+				wg.Done()
+			})
+		}
+	}
+	wg.Wait()
+
+	for _, roomID := range rooms {
+		got := handled[roomID]
+		if len(got) != perRoom {
+			t.Fatalf("%s handled %d events, want %d", roomID, len(got), perRoom)
+		}
+		for i, seq := range got {
+			if seq != i {
+				t.Fatalf("%s handled out of order: %v", roomID, got)
+			}
+		}
+	}
+}
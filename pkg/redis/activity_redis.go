@@ -0,0 +1,186 @@
+package redis
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"solo/pkg/types/commontype"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const activityScheduleKey = "activities:schedule"
+
+// 방 활동 작업 예약 (score: 실행 시각)
+func (r *RedisClient) ScheduleActivityJob(job commontype.ActivityJob, runAt time.Time) error {
+	member, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("failed to marshal activity job: %v", err)
+	}
+
+	err = r.Client.ZAdd(ctx, activityScheduleKey, &redis.Z{
+		Score:  float64(runAt.Unix()),
+		Member: string(member),
+	}).Err()
+	if err != nil {
+		log.Printf("Failed to schedule activity %s (%s) for RoomID %s: %v", job.Activity, job.Phase, job.RoomID, err)
+		return err
+	}
+
+	log.Printf("Activity %s (%s) scheduled for RoomID %s at %s", job.Activity, job.Phase, job.RoomID, runAt.Format(time.RFC3339))
+	return nil
+}
+
+// 실행 시각이 지난 작업 조회 및 선점
+// ZREM에 성공한 작업만 반환하므로 여러 인스턴스가 동시에 조회해도 한 번만 실행됨
+func (r *RedisClient) PopDueActivityJobs(now time.Time) ([]commontype.ActivityJob, error) {
+	members, err := r.Client.ZRangeByScore(ctx, activityScheduleKey, &redis.ZRangeBy{
+		Min: "-inf",
+		Max: strconv.FormatInt(now.Unix(), 10),
+	}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get due activity jobs: %v", err)
+	}
+
+	var jobs []commontype.ActivityJob
+	for _, member := range members {
+		removed, err := r.Client.ZRem(ctx, activityScheduleKey, member).Result()
+		if err != nil {
+			log.Printf("Failed to claim activity job %s: %v", member, err)
+			continue
+		}
+		if removed == 0 {
+			continue // 다른 인스턴스가 먼저 선점
+		}
+
+		var job commontype.ActivityJob
+		if err := json.Unmarshal([]byte(member), &job); err != nil {
+			log.Printf("Failed to unmarshal activity job %s: %v", member, err)
+			continue
+		}
+		jobs = append(jobs, job)
+	}
+
+	return jobs, nil
+}
+
+// 활동 스케줄 도입 전 방식으로 예약된 밸런스 게임 시작/종료 타이머
+const (
+	legacyBalanceGameRoomsKey  = "rooms:balance_game"
+	legacyBalanceGameFinishKey = "forms:balance_game_finish"
+)
+
+// 이전 방식으로 예약된 밸런스 게임 시작을 하나 꺼내 남은 시간과 함께 반환 (없으면 빈 문자열)
+func (r *RedisClient) PopLegacyBalanceGameStart() (string, time.Duration, error) {
+	return r.popLegacyTimer(legacyBalanceGameRoomsKey, "balance_game_timer:%s")
+}
+
+// 이전 방식으로 예약된 밸런스 게임 종료를 하나 꺼내 남은 시간과 함께 반환 (없으면 빈 문자열)
+func (r *RedisClient) PopLegacyBalanceGameFinish() (string, time.Duration, error) {
+	return r.popLegacyTimer(legacyBalanceGameFinishKey, "balance_game_finish:%s")
+}
+
+// SPOP으로 꺼내므로 여러 인스턴스가 동시에 옮겨도 한 번만 처리됨
+func (r *RedisClient) popLegacyTimer(setKey, timerKeyFormat string) (string, time.Duration, error) {
+	id, err := r.Client.SPop(ctx, setKey).Result()
+	if err == redis.Nil {
+		return "", 0, nil
+	} else if err != nil {
+		return "", 0, fmt.Errorf("failed to pop legacy timer from %s: %v", setKey, err)
+	}
+
+	// 남은 시간을 읽지 못하거나 이미 만료됐으면 바로 실행
+	timerKey := fmt.Sprintf(timerKeyFormat, id)
+	remaining, err := r.Client.TTL(ctx, timerKey).Result()
+	if err != nil {
+		log.Printf("Failed to get remaining time of legacy timer %s: %v", timerKey, err)
+		remaining = 0
+	}
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	if err := r.Client.Del(ctx, timerKey).Err(); err != nil {
+		log.Printf("Failed to delete legacy timer %s: %v", timerKey, err)
+	}
+
+	return id, remaining, nil
+}
+
+// 첫인상 선택 저장 (라운드 종료 전까지 변경 가능)
+func (r *RedisClient) SaveFirstImpression(roomID, instanceID string, userID, selectedUserID int, ttl time.Duration) error {
+	key := fmt.Sprintf("first_impression:%s:%s", roomID, instanceID)
+
+	pipe := r.Client.TxPipeline()
+	pipe.HSet(ctx, key, strconv.Itoa(userID), strconv.Itoa(selectedUserID))
+	pipe.Expire(ctx, key, ttl)
+	_, err := pipe.Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to save first impression for room %s, user %d: %v", roomID, userID, err)
+	}
+	return nil
+}
+
+// 첫인상 선택 전체 조회 (key: 선택한 유저, value: 선택된 유저)
+func (r *RedisClient) GetFirstImpressions(roomID, instanceID string) (map[int]int, error) {
+	key := fmt.Sprintf("first_impression:%s:%s", roomID, instanceID)
+
+	values, err := r.Client.HGetAll(ctx, key).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get first impressions for room %s: %v", roomID, err)
+	}
+
+	picks := make(map[int]int, len(values))
+	for userIDStr, selectedStr := range values {
+		userID, err1 := strconv.Atoi(userIDStr)
+		selectedUserID, err2 := strconv.Atoi(selectedStr)
+		if err1 != nil || err2 != nil {
+			log.Printf("Invalid first impression entry in room %s: %s=%s", roomID, userIDStr, selectedStr)
+			continue
+		}
+		picks[userID] = selectedUserID
+	}
+
+	return picks, nil
+}
+
+// 진행 중인 첫인상 라운드 기록 (라운드 시간 동안만 유지)
+func (r *RedisClient) SetActiveFirstImpression(roomID, instanceID string, ttl time.Duration) error {
+	key := fmt.Sprintf("first_impression_active:%s", roomID)
+	err := r.Client.Set(ctx, key, instanceID, ttl).Err()
+	if err != nil {
+		return fmt.Errorf("failed to set active first impression for room %s: %v", roomID, err)
+	}
+	return nil
+}
+
+// 진행 중인 첫인상 라운드 조회 (없으면 빈 문자열)
+func (r *RedisClient) GetActiveFirstImpression(roomID string) (string, error) {
+	key := fmt.Sprintf("first_impression_active:%s", roomID)
+	instanceID, err := r.Client.Get(ctx, key).Result()
+	if err == redis.Nil {
+		return "", nil
+	} else if err != nil {
+		return "", fmt.Errorf("failed to get active first impression for room %s: %v", roomID, err)
+	}
+	return instanceID, nil
+}
+
+// 같은 라운드일 때만 진행 중 기록 삭제 (다음 라운드 기록은 유지)
+var clearActiveFirstImpressionScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+func (r *RedisClient) ClearActiveFirstImpression(roomID, instanceID string) error {
+	key := fmt.Sprintf("first_impression_active:%s", roomID)
+	err := clearActiveFirstImpressionScript.Run(ctx, r.Client, []string{key}, instanceID).Err()
+	if err != nil && err != redis.Nil {
+		return fmt.Errorf("failed to clear active first impression for room %s: %v", roomID, err)
+	}
+	return nil
+}
@@ -44,6 +44,15 @@ func (r *RedisClient) GetAllRoomsFromRedis() ([]string, error) {
 	return roomIDs, nil
 }
 
+// 만료된 방 종료 선점 (방 목록에서 먼저 뺀 파드만 true)
+func (r *RedisClient) ClaimRoomClose(roomID string) (bool, error) {
+	removed, err := r.Client.SRem(ctx, "rooms:list", roomID).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to claim room %s close: %v", roomID, err)
+	}
+	return removed > 0, nil
+}
+
 // 채팅방 타임아웃 설정
 func (r *RedisClient) SetRoomTimeout(roomID string, duration time.Duration) error {
 	err := r.Client.Set(ctx, roomID, duration.Seconds(), duration).Err()
@@ -80,6 +89,19 @@ func (r *RedisClient) GetRoomRemainingTime(roomID string) (int, error) {
 	return int(ttl.Seconds()), nil
 }
 
+// 남은 시간 알림 구간 선점 (이미 알림을 보낸 구간이면 false)
+// TTL을 구간 길이로 두어 방 시간이 연장되면 다시 알림을 보낼 수 있도록 함
+func (r *RedisClient) ClaimRoomRemainingMilestone(roomID, phase string, milestone time.Duration) (bool, error) {
+	key := fmt.Sprintf("room_remaining:%s:%s:%d", roomID, phase, int(milestone.Seconds()))
+	claimed, err := r.Client.SetNX(ctx, key, 1, milestone).Result()
+	if err != nil {
+		log.Printf("Failed to claim remaining milestone %v for RoomID %s: %v", milestone, roomID, err)
+		return false, err
+	}
+
+	return claimed, nil
+}
+
 func (r *RedisClient) GetRoomStatus(roomID string) (int, error) {
 	statusKey := fmt.Sprintf("room_status:%s", roomID)
 	statusStr, err := r.Client.Get(ctx, statusKey).Result()
@@ -97,149 +119,3 @@ func (r *RedisClient) GetRoomStatus(roomID string) (int, error) {
 	log.Printf("Get status for room %s: %d", roomID, status)
 	return status, nil
 }
-
-// 밸런스 게임 시작 타이머 설정
-func (r *RedisClient) SetBalanceGameTimer(roomID string, duration time.Duration) error {
-	ctx := context.Background()
-
-	// 방 Timeout 목록에 추가
-	err := r.Client.Set(ctx, fmt.Sprintf("balance_game_timer:%s", roomID), duration.Seconds(), duration).Err()
-	if err != nil {
-		log.Printf("Failed to set balance game timer for RoomID %s: %v", roomID, err)
-		return err
-	}
-
-	// 밸런스 게임 타이머 목록에 추가
-	err = r.Client.SAdd(ctx, "rooms:balance_game", roomID).Err()
-	if err != nil {
-		log.Printf("Failed to add RoomID %s to balance game rooms: %v", roomID, err)
-		return err
-	}
-
-	log.Printf("Balance game timer set for RoomID %s: %v seconds", roomID, duration.Seconds())
-	return nil
-}
-
-// 밸런스 게임 타이머가 설정된 모든 방 조회
-func (r *RedisClient) GetAllBalanceGameRooms() ([]string, error) {
-	ctx := context.Background()
-
-	roomIDs, err := r.Client.SMembers(ctx, "rooms:balance_game").Result()
-	if err != nil {
-		log.Printf("Failed to get balance game rooms from Redis: %v", err)
-		return nil, err
-	}
-
-	return roomIDs, nil
-}
-
-// 밸런스 게임 타이머 남은 시간 조회
-func (r *RedisClient) GetBalanceGameRemainingTime(roomID string) (int, error) {
-	ctx := context.Background()
-
-	ttl, err := r.Client.TTL(ctx, fmt.Sprintf("balance_game_timer:%s", roomID)).Result()
-	if err != nil {
-		log.Printf("Failed to get remaining time for balance game in room %s: %v", roomID, err)
-		return 0, err
-	}
-
-	if ttl <= 0 {
-		return 0, nil
-	}
-
-	return int(ttl.Seconds()), nil
-}
-
-// 밸런스 게임 타이머에서 방 제거
-func (r *RedisClient) RemoveBalanceGameRoom(roomID string) error {
-	ctx := context.Background()
-
-	// 타이머 키 삭제
-	err := r.Client.Del(ctx, fmt.Sprintf("balance_game_timer:%s", roomID)).Err()
-	if err != nil {
-		log.Printf("Failed to delete balance game timer for room %s: %v", roomID, err)
-		return err
-	}
-
-	// 방 목록에서 제거
-	err = r.Client.SRem(ctx, "rooms:balance_game", roomID).Err()
-	if err != nil {
-		log.Printf("Failed to remove room %s from balance game rooms: %v", roomID, err)
-		return err
-	}
-
-	return nil
-}
-
-// 밸런스 게임 종료 타이머 설정
-func (r *RedisClient) SetBalanceGameFinishTimer(formID string, duration time.Duration) error {
-	ctx := context.Background()
-
-	// form Timeout 목록에 추가
-	err := r.Client.Set(ctx, fmt.Sprintf("balance_game_finish:%s", formID), duration.Seconds(), duration).Err()
-	if err != nil {
-		log.Printf("Failed to set balance game finish timer for FormID %s: %v", formID, err)
-		return err
-	}
-
-	// 밸런스 게임 종료 타이머 목록에 추가
-	err = r.Client.SAdd(ctx, "forms:balance_game_finish", formID).Err()
-	if err != nil {
-		log.Printf("Failed to add FormID %s to balance game finish forms: %v", formID, err)
-		return err
-	}
-
-	log.Printf("Balance game finish timer set for FormID %s: %v seconds", formID, duration.Seconds())
-	return nil
-}
-
-// 밸런스 게임 종료 타이머가 설정된 모든 form 조회
-func (r *RedisClient) GetAllBalanceGameFinishForms() ([]string, error) {
-	ctx := context.Background()
-
-	formIDs, err := r.Client.SMembers(ctx, "forms:balance_game_finish").Result()
-	if err != nil {
-		log.Printf("Failed to get balance game finish forms from Redis: %v", err)
-		return nil, err
-	}
-
-	return formIDs, nil
-}
-
-// 밸런스 게임 종료 타이머 남은 시간 조회
-func (r *RedisClient) GetBalanceGameFinishRemainingTime(formID string) (int, error) {
-	ctx := context.Background()
-
-	ttl, err := r.Client.TTL(ctx, fmt.Sprintf("balance_game_finish:%s", formID)).Result()
-	if err != nil {
-		log.Printf("Failed to get remaining time for balance game finish in form %s: %v", formID, err)
-		return 0, err
-	}
-
-	if ttl <= 0 {
-		return 0, nil
-	}
-
-	return int(ttl.Seconds()), nil
-}
-
-// 밸런스 게임 종료 타이머에서 form 제거
-func (r *RedisClient) RemoveBalanceGameFinishForm(formID string) error {
-	ctx := context.Background()
-
-	// 타이머 키 삭제
-	err := r.Client.Del(ctx, fmt.Sprintf("balance_game_finish:%s", formID)).Err()
-	if err != nil {
-		log.Printf("Failed to delete balance game finish timer for form %s: %v", formID, err)
-		return err
-	}
-
-	// form 목록에서 제거
-	err = r.Client.SRem(ctx, "forms:balance_game_finish", formID).Err()
-	if err != nil {
-		log.Printf("Failed to remove form %s from balance game finish forms: %v", formID, err)
-		return err
-	}
-
-	return nil
-}
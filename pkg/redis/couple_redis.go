@@ -0,0 +1,208 @@
+package redis
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+)
+
+const coupleRoomListKey = "couple_rooms:list"
+
+// 커플 채팅방 만료 타이머 설정 (게임방 타이머와 별도로 관리)
+func (r *RedisClient) SetCoupleRoomTimeout(roomID string, duration time.Duration) error {
+	key := fmt.Sprintf("couple_room:%s", roomID)
+	err := r.Client.Set(ctx, key, duration.Seconds(), duration).Err()
+	if err != nil {
+		log.Printf("Failed to set couple room timeout for RoomID %s: %v", roomID, err)
+		return err
+	}
+
+	err = r.Client.SAdd(ctx, coupleRoomListKey, roomID).Err()
+	if err != nil {
+		log.Printf("Failed to add RoomID %s to couple rooms list: %v", roomID, err)
+		return err
+	}
+
+	log.Printf("Couple room timeout set for RoomID %s: %v", roomID, duration)
+	return nil
+}
+
+// Redis에서 모든 커플 채팅방 ID 가져오기
+func (r *RedisClient) GetAllCoupleRoomsFromRedis() ([]string, error) {
+	roomIDs, err := r.Client.SMembers(ctx, coupleRoomListKey).Result()
+	if err != nil {
+		log.Printf("Failed to get couple room list from Redis: %v", err)
+		return nil, err
+	}
+	return roomIDs, nil
+}
+
+// 커플 채팅방 남은 시간 조회 (초), 만료되었으면 0
+func (r *RedisClient) GetCoupleRoomRemainingTime(roomID string) (int, error) {
+	ttl, err := r.Client.TTL(ctx, fmt.Sprintf("couple_room:%s", roomID)).Result()
+	if err != nil {
+		log.Printf("Failed to get remaining time for couple RoomID %s: %v", roomID, err)
+		return 0, err
+	}
+
+	if ttl <= 0 {
+		return 0, nil
+	}
+	return int(ttl.Seconds()), nil
+}
+
+// 만료된 커플 채팅방 종료 선점 (목록에서 먼저 뺀 파드만 true)
+func (r *RedisClient) ClaimCoupleRoomClose(roomID string) (bool, error) {
+	removed, err := r.Client.SRem(ctx, coupleRoomListKey, roomID).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to claim couple room %s close: %v", roomID, err)
+	}
+	return removed > 0, nil
+}
+
+// 커플 채팅방 타이머, 연장 동의, 연락처 교환 정보 제거
+func (r *RedisClient) RemoveCoupleRoomFromRedis(roomID string) error {
+	err := r.Client.Del(ctx,
+		fmt.Sprintf("couple_room:%s", roomID),
+		fmt.Sprintf("couple_extend:%s", roomID),
+		fmt.Sprintf("couple_contact:%s", roomID),
+		fmt.Sprintf("couple_contact_confirm:%s", roomID),
+	).Err()
+	if err != nil {
+		log.Printf("Failed to delete couple room %s from Redis: %v", roomID, err)
+		return err
+	}
+
+	err = r.Client.SRem(ctx, coupleRoomListKey, roomID).Err()
+	if err != nil {
+		log.Printf("Failed to remove RoomID %s from couple rooms list: %v", roomID, err)
+		return err
+	}
+
+	return nil
+}
+
+// 커플 채팅방 연장 동의/철회 후 현재 동의한 유저 ID 목록 반환
+func (r *RedisClient) SetCoupleExtendAgree(roomID string, userID int, agree bool, ttl time.Duration) ([]int, error) {
+	key := fmt.Sprintf("couple_extend:%s", roomID)
+
+	pipe := r.Client.TxPipeline()
+	if agree {
+		pipe.SAdd(ctx, key, strconv.Itoa(userID))
+		pipe.Expire(ctx, key, ttl)
+	} else {
+		pipe.SRem(ctx, key, strconv.Itoa(userID))
+	}
+	members := pipe.SMembers(ctx, key)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("failed to set couple extend agreement for room %s, user %d: %v", roomID, userID, err)
+	}
+
+	var userIDs []int
+	for _, member := range members.Val() {
+		id, err := strconv.Atoi(member)
+		if err != nil {
+			continue
+		}
+		userIDs = append(userIDs, id)
+	}
+	return userIDs, nil
+}
+
+// 커플 채팅방 연장 적용 선점 (동의 기록을 지운 쪽만 true, 동시에 마지막 동의가 들어와도 한 번만 연장)
+// 동의 기록이 초기화되므로 다음 연장은 다시 동의를 받음
+func (r *RedisClient) ClaimCoupleExtend(roomID string) (bool, error) {
+	removed, err := r.Client.Del(ctx, fmt.Sprintf("couple_extend:%s", roomID)).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to claim couple extend for room %s: %v", roomID, err)
+	}
+	return removed > 0, nil
+}
+
+// 커플 채팅방 연락처 교환 상태
+type CoupleContactState struct {
+	Handles          map[int]string
+	ConfirmedUserIDs []int
+}
+
+// 연락처 제출 (재제출하면 본인 확인은 초기화)
+func (r *RedisClient) SubmitCoupleContact(roomID string, userID int, handle string, ttl time.Duration) (*CoupleContactState, error) {
+	handleKey := fmt.Sprintf("couple_contact:%s", roomID)
+	confirmKey := fmt.Sprintf("couple_contact_confirm:%s", roomID)
+
+	pipe := r.Client.TxPipeline()
+	pipe.HSet(ctx, handleKey, strconv.Itoa(userID), handle)
+	pipe.SRem(ctx, confirmKey, strconv.Itoa(userID))
+	pipe.Expire(ctx, handleKey, ttl)
+	handles := pipe.HGetAll(ctx, handleKey)
+	members := pipe.SMembers(ctx, confirmKey)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("failed to submit couple contact for room %s, user %d: %v", roomID, userID, err)
+	}
+
+	return newCoupleContactState(handles.Val(), members.Val()), nil
+}
+
+// 연락처 공개 확인 (제출하지 않았으면 확인되지 않음)
+func (r *RedisClient) ConfirmCoupleContact(roomID string, userID int, ttl time.Duration) (*CoupleContactState, error) {
+	handleKey := fmt.Sprintf("couple_contact:%s", roomID)
+	confirmKey := fmt.Sprintf("couple_contact_confirm:%s", roomID)
+
+	exists, err := r.Client.HExists(ctx, handleKey, strconv.Itoa(userID)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to check couple contact for room %s, user %d: %v", roomID, userID, err)
+	}
+
+	pipe := r.Client.TxPipeline()
+	if exists {
+		pipe.SAdd(ctx, confirmKey, strconv.Itoa(userID))
+		pipe.Expire(ctx, confirmKey, ttl)
+	}
+	handles := pipe.HGetAll(ctx, handleKey)
+	members := pipe.SMembers(ctx, confirmKey)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("failed to confirm couple contact for room %s, user %d: %v", roomID, userID, err)
+	}
+
+	return newCoupleContactState(handles.Val(), members.Val()), nil
+}
+
+// 연락처 공개를 위해 제출된 연락처를 꺼내고 교환 상태 삭제 (동시에 호출되어도 한 번만 반환)
+func (r *RedisClient) TakeCoupleContacts(roomID string) (map[int]string, error) {
+	handleKey := fmt.Sprintf("couple_contact:%s", roomID)
+	confirmKey := fmt.Sprintf("couple_contact_confirm:%s", roomID)
+
+	pipe := r.Client.TxPipeline()
+	handles := pipe.HGetAll(ctx, handleKey)
+	pipe.Del(ctx, handleKey, confirmKey)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("failed to take couple contacts for room %s: %v", roomID, err)
+	}
+
+	return newCoupleContactState(handles.Val(), nil).Handles, nil
+}
+
+// 연락처 교환 취소 (양쪽 제출 내용 모두 삭제)
+func (r *RedisClient) ClearCoupleContact(roomID string) error {
+	return r.Client.Del(ctx, fmt.Sprintf("couple_contact:%s", roomID), fmt.Sprintf("couple_contact_confirm:%s", roomID)).Err()
+}
+
+func newCoupleContactState(handles map[string]string, confirmed []string) *CoupleContactState {
+	state := &CoupleContactState{Handles: make(map[int]string, len(handles))}
+	for member, handle := range handles {
+		id, err := strconv.Atoi(member)
+		if err != nil {
+			continue
+		}
+		state.Handles[id] = handle
+	}
+	for _, member := range confirmed {
+		id, err := strconv.Atoi(member)
+		if err != nil {
+			continue
+		}
+		state.ConfirmedUserIDs = append(state.ConfirmedUserIDs, id)
+	}
+	return state
+}
@@ -17,11 +17,42 @@ func (r *RedisClient) RegisterActiveUser(userID int, serverID string) error {
 	return r.Client.HSet(ctx, "client:active", strconv.Itoa(userID), serverID).Err()
 }
 
-func (r *RedisClient) UnregisterActiveUser(userID int) error {
-	return r.Client.HDel(ctx, "client:active", strconv.Itoa(userID)).Err()
+// 해당 서버에 등록된 경우에만 제거 (다른 파드로 재접속한 등록은 유지)
+var unregisterActiveUserScript = redis.NewScript(`
+if redis.call("HGET", KEYS[1], ARGV[1]) == ARGV[2] then
+	return redis.call("HDEL", KEYS[1], ARGV[1])
+end
+return 0
+`)
+
+func (r *RedisClient) UnregisterActiveUser(userID int, serverID string) error {
+	return unregisterActiveUserScript.Run(ctx, r.Client, []string{"client:active"}, strconv.Itoa(userID), serverID).Err()
 }
 
-func (r *RedisClient) GetActiveUserIDs(roomID string) ([]int, error) {
+// 서버(파드)에 등록된 활성 사용자만 한 번에 제거 (조회와 삭제 사이에 다른 파드로 재접속한 등록은 유지)
+var unregisterServerActiveUsersScript = redis.NewScript(`
+local entries = redis.call("HGETALL", KEYS[1])
+local removed = 0
+for i = 1, #entries, 2 do
+	if entries[i + 1] == ARGV[1] then
+		removed = removed + redis.call("HDEL", KEYS[1], entries[i])
+	end
+end
+return removed
+`)
+
+// 서버(파드)에 등록된 활성 사용자 일괄 제거
+func (r *RedisClient) UnregisterServerActiveUsers(serverID string) (int, error) {
+	removed, err := unregisterServerActiveUsersScript.Run(ctx, r.Client, []string{"client:active"}, serverID).Int()
+	if err != nil {
+		return 0, fmt.Errorf("failed to unregister active users of server %s: %v", serverID, err)
+	}
+
+	return removed, nil
+}
+
+// 방 멤버 중 해당 서버(파드)에 연결된 활성 사용자 조회
+func (r *RedisClient) GetActiveUserIDs(roomID, serverID string) ([]int, error) {
 	// Step 1: Room의 사용자 ID 리스트 가져오기
 	// TODO: MongoDB 시작 시 Redis와 동기화 작업이 필요함
 	roomKey := fmt.Sprintf("room:%s", roomID)
@@ -37,7 +68,7 @@ func (r *RedisClient) GetActiveUserIDs(roomID string) ([]int, error) {
 	for _, sUserID := range userIDs {
 		activeKey := "client:active"
 		active, err := r.Client.HGet(ctx, activeKey, sUserID).Result()
-		if err == redis.Nil || active != commontype.DEFAULT_TEMP_SERVER_ID {
+		if err == redis.Nil || active != serverID {
 			continue
 		} else if err != nil {
 			return nil, fmt.Errorf("failed to check active status for user %s: %v", sUserID, err)
@@ -233,6 +264,34 @@ func (r *RedisClient) SaveUserChoice(roomID string, userID, selectedUserID int)
 	return nil
 }
 
+// 특정 유저의 최종 선택 조회 (선택하지 않았으면 false)
+func (r *RedisClient) GetUserChoice(roomID string, userID int) (int, bool, error) {
+	choiceKey := fmt.Sprintf("final_choice_room:%s", roomID)
+	selected, err := r.Client.HGet(ctx, choiceKey, strconv.Itoa(userID)).Result()
+	if err == redis.Nil {
+		return 0, false, nil
+	} else if err != nil {
+		return 0, false, fmt.Errorf("failed to get user choice for room %s, user %d: %v", roomID, userID, err)
+	}
+
+	selectedUserID, err := strconv.Atoi(selected)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to convert user choice for room %s, user %d: %v", roomID, userID, err)
+	}
+	return selectedUserID, true, nil
+}
+
+// 특정 유저의 최종 선택 철회
+func (r *RedisClient) RemoveUserChoice(roomID string, userID int) error {
+	choiceKey := fmt.Sprintf("final_choice_room:%s", roomID)
+	err := r.Client.HDel(ctx, choiceKey, strconv.Itoa(userID)).Err()
+	if err != nil {
+		return fmt.Errorf("failed to remove user choice for room %s, user %d: %v", roomID, userID, err)
+	}
+	log.Printf("User %d withdrew choice in room %s", userID, roomID)
+	return nil
+}
+
 func (r *RedisClient) IsAllChoicesCompleted(roomID string, totalUsers int64) (bool, error) {
 	choiceKey := fmt.Sprintf("final_choice_room:%s", roomID)
 	choiceCount, err := r.Client.HLen(ctx, choiceKey).Result()
@@ -272,6 +331,26 @@ func (r *RedisClient) GetAllChoices(roomID string) (*stype.FinalChoiceResultMess
 	return finalChoices, nil
 }
 
+// 최종 선택 결과 공개 선점 (이미 공개된 방이면 false)
+func (r *RedisClient) ClaimFinalChoiceBroadcast(roomID string) (bool, error) {
+	key := fmt.Sprintf("final_choice_broadcast:%s", roomID)
+	claimed, err := r.Client.SetNX(ctx, key, 1, 24*time.Hour).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to claim final choice broadcast for room %s: %v", roomID, err)
+	}
+	return claimed, nil
+}
+
+// 최종 선택 결과 공개 선점 해제 (공개/저장 실패 시 재시도할 수 있도록)
+func (r *RedisClient) ReleaseFinalChoiceBroadcast(roomID string) error {
+	key := fmt.Sprintf("final_choice_broadcast:%s", roomID)
+	err := r.Client.Del(ctx, key).Err()
+	if err != nil {
+		return fmt.Errorf("failed to release final choice broadcast for room %s: %v", roomID, err)
+	}
+	return nil
+}
+
 func (r *RedisClient) ClearFinalChoiceRoom(roomID string) error {
 	choiceKey := fmt.Sprintf("final_choice_room:%s", roomID)
 	err := r.Client.Del(ctx, choiceKey).Err()
@@ -281,3 +360,133 @@ func (r *RedisClient) ClearFinalChoiceRoom(roomID string) error {
 	log.Printf("Cleared final choice data for room %s", roomID)
 	return nil
 }
+
+// 투표 마감 타이머가 만료 전에 투표를 읽을 수 있도록 Redis 키는 조금 더 유지
+const roomExtendVoteExpiryGrace = 10 * time.Second
+
+// 방 연장 투표 시작 (이미 진행 중인 투표가 있으면 false)
+func (r *RedisClient) StartRoomExtendVote(roomID, voteID string, proposerID int, duration time.Duration) (bool, error) {
+	voteKey := fmt.Sprintf("room_extend_vote:%s", roomID)
+	started, err := r.Client.HSetNX(ctx, voteKey, "vote_id", voteID).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to start extend vote for room %s: %v", roomID, err)
+	}
+	if !started {
+		return false, nil
+	}
+
+	// 제안자는 찬성으로 처리
+	err = r.Client.HSet(ctx, voteKey, "proposer", proposerID, strconv.Itoa(proposerID), "1").Err()
+	if err != nil {
+		return false, fmt.Errorf("failed to save proposer vote for room %s: %v", roomID, err)
+	}
+
+	err = r.Client.Expire(ctx, voteKey, duration+roomExtendVoteExpiryGrace).Err()
+	if err != nil {
+		return false, fmt.Errorf("failed to set extend vote expiry for room %s: %v", roomID, err)
+	}
+
+	return true, nil
+}
+
+// 방 연장 투표 (진행 중인 투표가 없으면 false)
+func (r *RedisClient) SetRoomExtendVote(roomID string, userID int, agree bool) (bool, error) {
+	voteKey := fmt.Sprintf("room_extend_vote:%s", roomID)
+	exists, err := r.Client.Exists(ctx, voteKey).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to check extend vote for room %s: %v", roomID, err)
+	}
+	if exists == 0 {
+		return false, nil
+	}
+
+	value := "0"
+	if agree {
+		value = "1"
+	}
+
+	err = r.Client.HSet(ctx, voteKey, strconv.Itoa(userID), value).Err()
+	if err != nil {
+		return false, fmt.Errorf("failed to save extend vote for room %s: %v", roomID, err)
+	}
+
+	return true, nil
+}
+
+// 방 연장 투표 현황 조회 (진행 중인 투표가 없으면 nil)
+func (r *RedisClient) GetRoomExtendVote(roomID string) (*commontype.RoomExtendVote, error) {
+	voteKey := fmt.Sprintf("room_extend_vote:%s", roomID)
+	votesMap, err := r.Client.HGetAll(ctx, voteKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get extend votes for room %s: %v", roomID, err)
+	}
+	if len(votesMap) == 0 {
+		return nil, nil
+	}
+
+	ttl, err := r.Client.TTL(ctx, voteKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get extend vote ttl for room %s: %v", roomID, err)
+	}
+
+	vote := &commontype.RoomExtendVote{
+		VoteID:    votesMap["vote_id"],
+		Votes:     make(map[int]bool, len(votesMap)),
+		ExpiresAt: time.Now().Add(ttl - roomExtendVoteExpiryGrace),
+	}
+	for field, value := range votesMap {
+		if field == "proposer" {
+			vote.ProposerID, _ = strconv.Atoi(value)
+			continue
+		}
+
+		userID, err := strconv.Atoi(field)
+		if err != nil {
+			continue
+		}
+		vote.Votes[userID] = value == "1"
+	}
+
+	return vote, nil
+}
+
+// 같은 투표일 때만 삭제 (이전 투표의 타이머가 새 투표를 지우지 않도록)
+var clearRoomExtendVoteScript = redis.NewScript(`
+if redis.call("HGET", KEYS[1], "vote_id") == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// 방 연장 투표 종료 (먼저 종료한 쪽만 true)
+func (r *RedisClient) ClearRoomExtendVote(roomID, voteID string) (bool, error) {
+	voteKey := fmt.Sprintf("room_extend_vote:%s", roomID)
+	deleted, err := clearRoomExtendVoteScript.Run(ctx, r.Client, []string{voteKey}, voteID).Int()
+	if err != nil {
+		return false, fmt.Errorf("failed to clear extend vote for room %s: %v", roomID, err)
+	}
+
+	return deleted > 0, nil
+}
+
+// 구간별 귓속말 사용 횟수 증가 (증가 후 값 반환)
+func (r *RedisClient) IncrWhisperCount(roomID, phase string, userID int) (int, error) {
+	countKey := fmt.Sprintf("whisper_count:%s:%s:%d", roomID, phase, userID)
+	count, err := r.Client.Incr(ctx, countKey).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to increase whisper count for room %s: %v", roomID, err)
+	}
+
+	// 방이 종료된 뒤에는 필요 없으므로 만료 설정
+	if count == 1 {
+		r.Client.Expire(ctx, countKey, commontype.GameRunningTime*2)
+	}
+
+	return int(count), nil
+}
+
+// 귓속말 사용 횟수 감소 (전송 실패 시 되돌림)
+func (r *RedisClient) DecrWhisperCount(roomID, phase string, userID int) error {
+	countKey := fmt.Sprintf("whisper_count:%s:%s:%d", roomID, phase, userID)
+	return r.Client.Decr(ctx, countKey).Err()
+}
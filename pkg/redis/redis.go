@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"solo/pkg/types/commontype"
 	"time"
 
 	"github.com/go-redis/redis/v8"
@@ -114,11 +115,33 @@ func (r *RedisClient) SetRoomStatus(roomID string, status int) error {
 	return nil
 }
 
+// 채팅방 타입 설정 (메시지마다 MongoDB를 조회하지 않도록 캐시)
+func (r *RedisClient) SetRoomType(roomID string, roomType int) error {
+	typeKey := fmt.Sprintf("room_type:%s", roomID)
+	err := r.Client.Set(ctx, typeKey, roomType, commontype.RoomTypeCacheTTL).Err()
+	if err != nil {
+		return fmt.Errorf("failed to set type for room %s: %v", roomID, err)
+	}
+	return nil
+}
+
+// 채팅방 타입 조회 (저장되지 않은 방이면 false)
+func (r *RedisClient) GetRoomType(roomID string) (int, bool, error) {
+	typeKey := fmt.Sprintf("room_type:%s", roomID)
+	roomType, err := r.Client.Get(ctx, typeKey).Int()
+	if err == redis.Nil {
+		return 0, false, nil
+	} else if err != nil {
+		return 0, false, fmt.Errorf("failed to get type for room %s: %v", roomID, err)
+	}
+	return roomType, true, nil
+}
+
 func (r *RedisClient) RemoveRoomFromRedis(roomID string) error {
 	ctx := context.Background()
 
-	// Redis에서 방 제거
-	err := r.Client.Del(ctx, roomID).Err()
+	// Redis에서 방과 타입 캐시 제거
+	err := r.Client.Del(ctx, roomID, fmt.Sprintf("room_type:%s", roomID)).Err()
 	if err != nil {
 		log.Printf("Failed to delete room %s from Redis: %v", roomID, err)
 		return err
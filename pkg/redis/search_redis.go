@@ -0,0 +1,96 @@
+package redis
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	searchBackfillLeaseKey  = "search_backfill:lease"
+	searchBackfillCursorKey = "search_backfill:cursor"
+	searchBackfillDoneKey   = "search_backfill:done"
+)
+
+// 검색 토큰 보강 작업 점유 (다른 파드가 실행 중이면 false)
+func (r *RedisClient) AcquireSearchBackfillLease(holder string, lease time.Duration) (bool, error) {
+	acquired, err := r.Client.SetNX(ctx, searchBackfillLeaseKey, holder, lease).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire search backfill lease: %v", err)
+	}
+	return acquired, nil
+}
+
+// 점유한 파드인 경우에만 점유 시간 연장
+var renewSearchBackfillLeaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+func (r *RedisClient) RenewSearchBackfillLease(holder string, lease time.Duration) (bool, error) {
+	renewed, err := renewSearchBackfillLeaseScript.Run(ctx, r.Client, []string{searchBackfillLeaseKey}, holder, lease.Milliseconds()).Int()
+	if err != nil {
+		return false, fmt.Errorf("failed to renew search backfill lease: %v", err)
+	}
+	return renewed == 1, nil
+}
+
+// 점유한 파드인 경우에만 점유 해제
+var releaseSearchBackfillLeaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+func (r *RedisClient) ReleaseSearchBackfillLease(holder string) error {
+	err := releaseSearchBackfillLeaseScript.Run(ctx, r.Client, []string{searchBackfillLeaseKey}, holder).Err()
+	if err != nil && err != redis.Nil {
+		return fmt.Errorf("failed to release search backfill lease: %v", err)
+	}
+	return nil
+}
+
+// 마지막으로 처리한 메시지 ID 조회 (처음이면 빈 문자열)
+func (r *RedisClient) GetSearchBackfillCursor() (string, error) {
+	cursor, err := r.Client.Get(ctx, searchBackfillCursorKey).Result()
+	if err == redis.Nil {
+		return "", nil
+	} else if err != nil {
+		return "", fmt.Errorf("failed to get search backfill cursor: %v", err)
+	}
+	return cursor, nil
+}
+
+// 마지막으로 처리한 메시지 ID 저장 (재시작 시 이어서 처리)
+func (r *RedisClient) SetSearchBackfillCursor(messageID string) error {
+	err := r.Client.Set(ctx, searchBackfillCursorKey, messageID, 0).Err()
+	if err != nil {
+		return fmt.Errorf("failed to set search backfill cursor: %v", err)
+	}
+	return nil
+}
+
+// 검색 토큰 보강 완료 여부
+func (r *RedisClient) IsSearchBackfillDone() (bool, error) {
+	count, err := r.Client.Exists(ctx, searchBackfillDoneKey).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to check search backfill done: %v", err)
+	}
+	return count > 0, nil
+}
+
+// 검색 토큰 보강 완료 기록 (이후 시작 시 다시 실행하지 않음)
+func (r *RedisClient) MarkSearchBackfillDone() error {
+	pipe := r.Client.TxPipeline()
+	pipe.Set(ctx, searchBackfillDoneKey, 1, 0)
+	pipe.Del(ctx, searchBackfillCursorKey)
+	_, err := pipe.Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to mark search backfill done: %v", err)
+	}
+	return nil
+}
@@ -12,6 +12,8 @@ const (
 	ChatTypeFormResult = "form_result"
 	ChatTypeJoin       = "join"
 	ChatTypeLeave      = "leave"
+	ChatTypeWhisper    = "whisper"
+	ChatTypeImage      = "image"
 )
 
 const (
@@ -20,6 +22,14 @@ const (
 	BalanceFormVoteBlue = 1
 )
 
+// 밸런스 게임 승리 팀
+const (
+	BalanceGameWinnerNone = -1 // 투표 없음
+	BalanceGameWinnerRed  = 0
+	BalanceGameWinnerBlue = 1
+	BalanceGameWinnerTie  = 2
+)
+
 const (
 	MasterID = 0
 )
@@ -33,6 +43,123 @@ const (
 	RemoveRoomDataTimer    = 10 * time.Minute
 )
 
+// 방 데이터 정리 작업 (cleanup_jobs 컬렉션에 저장되어 재시작 후에도 이어서 실행)
+const (
+	CleanupJobStatusPending   = "pending"
+	CleanupJobStatusRunning   = "running"
+	CleanupJobStatusDone      = "done"
+	CleanupJobStatusCancelled = "cancelled" // 방이 종료 상태가 아니어서 정리하지 않음
+	CleanupJobStatusFailed    = "failed"    // 최대 재시도 횟수 초과, 수동 확인 필요
+
+	CleanupJobPollInterval = 30 * time.Second
+	CleanupJobLease        = 5 * time.Minute // 실행 중인 작업을 다른 워커가 가져가지 않는 시간
+	CleanupJobRetryDelay   = time.Minute     // 실패 시 (시도 횟수 × 지연) 후 재시도
+	CleanupJobMaxAttempts  = 10
+)
+
+// 방 데이터 보관 기본값 (환경 변수 ROOM_ARCHIVE_ENABLED, ROOM_ARCHIVE_RETENTION으로 변경 가능)
+const (
+	RoomArchiveRetention      = 180 * 24 * time.Hour // 보관 기간, 지나면 삭제
+	RoomArchiveInlineMaxBytes = 12 << 20             // 압축 크기가 이보다 크면 문서 대신 미디어 저장소에 파일로 보관
+	RoomArchivePurgeInterval  = time.Hour
+)
+
+// 메시지 리액션 (고정 이모지 세트)
+var ReactionTypes = []string{"❤️", "😂", "😮", "😢", "👍", "🔥"}
+
+// 채팅 이미지 기본값 (환경 변수 MEDIA_MAX_UPLOAD_BYTES, GAME_ROOM_IMAGE_PHASE로 변경 가능)
+const (
+	MediaMaxUploadBytes = 10 << 20 // 업로드 최대 크기 (10MB)
+	MediaThumbnailSize  = 320      // 썸네일 긴 변 (px)
+	GameRoomImagePhase  = ""       // 게임방 이미지 허용 시작 구간, 비어있으면 게임방은 허용 안 함
+
+	MediaDeleteTimeout = 10 * time.Second // 저장소 객체 하나를 삭제하는 제한 시간
+)
+
+// 답장 인용 미리보기 최대 글자 수
+const ReplySnippetLength = 50
+
+// 메시지 수정/보내기 취소 가능 시간 (환경 변수 MESSAGE_EDIT_WINDOW로 변경 가능)
+const MessageEditWindow = 5 * time.Minute
+
+// 구간별 귓속말 기본 허용 횟수 (환경 변수 WHISPER_LIMIT_CHAT, WHISPER_LIMIT_FINAL_CHOICE로 변경 가능)
+const (
+	WhisperLimitChat        = 3
+	WhisperLimitFinalChoice = 1
+)
+
+// 방 대화 시간 연장 기본값 (환경 변수 ROOM_EXTEND_*로 변경 가능)
+const (
+	RoomExtendWindow        = 10 * time.Minute // 종료 몇 분 전부터 연장 제안 가능
+	RoomExtendDuration      = 15 * time.Minute // 1회 연장 시간
+	RoomExtendVoteTimer     = 1 * time.Minute  // 투표 제한 시간
+	RoomExtendQuorumPercent = 60               // 찬성 정족수 (%)
+	RoomExtendMaxCount      = 1                // 방당 최대 연장 횟수
+	RoomExtendPointCost     = 0                // 연장 성공 시 제안자가 사용하는 게임 포인트
+)
+
+// 남은 시간 알림 구간
+const (
+	RoomPhaseChat        = "chat"
+	RoomPhaseFinalChoice = "final_choice"
+	RoomPhaseEnd         = "end"
+	RoomPhaseCouple      = "couple"
+)
+
+var (
+	RoomRemainingMilestones        = []time.Duration{30 * time.Minute, 10 * time.Minute, 5 * time.Minute, time.Minute}
+	FinalChoiceRemainingMilestones = []time.Duration{30 * time.Second, 10 * time.Second}
+	CoupleRemainingMilestones      = []time.Duration{24 * time.Hour, 3 * time.Hour, 30 * time.Minute}
+)
+
+// 커플 채팅방 기본값 (환경 변수 COUPLE_EXTEND_*, COUPLE_ROOM_RETENTION으로 변경 가능)
+const (
+	CoupleExtendWindow   = 24 * time.Hour    // 만료 몇 시간 전부터 연장 동의 가능
+	CoupleExtendDuration = CoupleRunningTime // 1회 연장 시간
+	CoupleExtendMaxCount = 1                 // 방당 최대 연장 횟수
+	CoupleRoomRetention  = 24 * time.Hour    // 종료 후 대화 내용 보관 시간
+	ContactHandleMaxLen  = 100               // 연락처 교환 시 연락처 최대 길이
+	ResumeMaxMessages    = 100               // 세션 복구 시 방별로 보내는 최대 메시지 수
+	ResumeMaxRooms       = 20                // 세션 복구 요청 한 번에 처리하는 최대 방 수
+)
+
+// 방 활동 (밸런스 게임 등)
+const (
+	ActivityBalanceGame     = "balance_game"
+	ActivityFirstImpression = "first_impression"
+)
+
+// 첫인상 선택 라운드 진행 시간
+// 기본 타임라인에는 없으며 FIRST_IMPRESSION_OFFSET(예: 20m) 또는 GAME_ROOM_TIMELINE으로 예약
+const FirstImpressionDuration = 3 * time.Minute
+
+const (
+	ActivityPhaseStart  = "start"
+	ActivityPhaseFinish = "finish"
+)
+
+// 종료 처리에 실패한 활동의 재시도 설정
+const (
+	ActivityFinishRetryDelay  = 30 * time.Second
+	ActivityFinishMaxAttempts = 5
+)
+
+// TimelineSlot은 방 타임라인에 예약되는 활동 (방 생성 시각 기준 Offset 후 시작)
+type TimelineSlot struct {
+	Activity string
+	Offset   time.Duration
+}
+
+// 게임 모드별 기본 타임라인 (환경 변수 GAME_ROOM_TIMELINE, COUPLE_ROOM_TIMELINE으로 변경 가능)
+var DefaultRoomTimelines = map[int][]TimelineSlot{
+	MATCH_GAME: {
+		{Activity: ActivityBalanceGame, Offset: BalanceGameStartTimer},
+		{Activity: ActivityBalanceGame, Offset: 25 * time.Minute},
+		{Activity: ActivityBalanceGame, Offset: 40 * time.Minute},
+	},
+	MATCH_COUPLE: {},
+}
+
 const (
 	MALE = iota
 	FEMALE
@@ -60,6 +187,23 @@ const (
 
 const DEFAULT_GAME_POINT = 10
 const DEFAULT_PAGE_SIZE = 20
+
+// 채팅방 메시지 검색
+const (
+	SearchPageSize          = 20  // 한 번에 반환하는 검색 결과 수
+	SearchContextSize       = 2   // 검색 결과 앞뒤로 함께 보여주는 메시지 수
+	SearchContextHits       = 5   // 앞뒤 메시지를 함께 조회하는 검색 결과 수 (페이지 앞쪽부터)
+	SearchBackfillBatchSize = 500 // 검색 토큰 보강 시 한 번에 처리하는 메시지 수
+	SearchQueryMaxLen       = 100 // 검색어 최대 길이
+)
+
+// 검색 토큰 보강 작업 점유 시간 (배치마다 연장, 실행 중인 파드가 죽으면 만료 후 다음에 시작하는 파드가 이어서 실행)
+const SearchBackfillLease = 2 * time.Minute
+
+// 채팅방 타입 캐시 유지 시간 (만료되면 다음 조회 때 MongoDB에서 다시 채움)
+const RoomTypeCacheTTL = 24 * time.Hour
+
+const DEFAULT_MAX_MESSAGE_LENGTH = 500
 const DEFAULT_TEMP_SERVER_ID = "game-server-1"
 
 const (
@@ -70,6 +214,13 @@ const (
 	RoomStatusGameEnd
 )
 
+// 최종 선택 결과 공개 정책
+const (
+	RevealPolicyFull           = iota // 모든 선택 공개
+	RevealPolicyMutualOnly            // 서로 선택했는지만 공개
+	RevealPolicyAnonymousTally        // 나를 선택한 인원 수만 공개
+)
+
 const (
 	YoungSoo = iota // 0부터 시작
 	YoungHo
@@ -138,6 +289,24 @@ type Gamer struct {
 	GameInfo GameInfo `gorm:"embedded;embeddedPrefix:game_info_" json:"game_info"`
 }
 
+// RoomExtendVote는 진행 중인 방 연장 투표
+type RoomExtendVote struct {
+	VoteID     string // 투표 식별자 (이전 투표의 타이머가 새 투표를 종료하지 않도록 구분)
+	ProposerID int
+	Votes      map[int]bool // key: userID, value: 찬성 여부
+	ExpiresAt  time.Time
+}
+
+// ActivityJob은 예약된 방 활동의 시작/종료 작업
+type ActivityJob struct {
+	Activity   string `json:"activity"`
+	Phase      string `json:"phase"`
+	RoomID     string `json:"room_id"`
+	Slot       int    `json:"slot"`                  // 타임라인 순서, 같은 활동이 여러 번 예약되어도 작업이 겹치지 않도록 구분
+	InstanceID string `json:"instance_id,omitempty"` // 종료 작업에서만 사용
+	Attempt    int    `json:"attempt,omitempty"`     // 종료 작업 재시도 횟수
+}
+
 type PushNotification struct {
 	Header  string
 	Content string
@@ -2,6 +2,7 @@ package eventtypes
 
 import (
 	"encoding/json"
+	"solo/pkg/models"
 	"solo/pkg/types/commontype"
 	"time"
 
@@ -26,20 +27,28 @@ const (
 	EventTypeRoomRemainTime     = "room.remain.time"
 	EventTypeFinalChoiceTimeout = "final.choice.timeout"
 	EventTypeVoteCommentChat    = "vote.comment.chat"
+	EventTypeCoupleRoomClose    = "couple.room.close"
+	EventTypeChatMediaDelete    = "chat.media.delete"
 	EventTypeLog                = "log"
 )
 
 type ChatEvent struct {
-	MessageId       primitive.ObjectID `bson:"_id,omitempty" json:"message_id"`
-	Type            string             `bson:"type" json:"type"`
-	RoomID          string             `bson:"room_id" json:"room_id"`
-	SenderID        int                `bson:"sender_id" json:"sender_id"`
-	Message         string             `bson:"message" json:"message"`
-	UnreadCount     int                `bson:"unread_count" json:"unread_count"`
-	InactiveUserIds []int              `bson:"inactive_user_ids" json:"inactive_user_ids"`
-	ReaderIds       []int              `bson:"reader_ids" json:"reader_ids"`
-	BalanceFormID   primitive.ObjectID `bson:"balance_form_id,omitempty" json:"balance_form_id,omitempty"`
-	CreatedAt       time.Time          `bson:"created_at" json:"created_at"`
+	MessageId       primitive.ObjectID        `bson:"_id,omitempty" json:"message_id"`
+	Type            string                    `bson:"type" json:"type"`
+	RoomID          string                    `bson:"room_id" json:"room_id"`
+	SenderID        int                       `bson:"sender_id" json:"sender_id"`
+	Message         string                    `bson:"message" json:"message"`
+	UnreadCount     int                       `bson:"unread_count" json:"unread_count"`
+	InactiveUserIds []int                     `bson:"inactive_user_ids" json:"inactive_user_ids"`
+	ReaderIds       []int                     `bson:"reader_ids" json:"reader_ids"`
+	BalanceFormID   primitive.ObjectID        `bson:"balance_form_id,omitempty" json:"balance_form_id,omitempty"`
+	BalanceResult   *models.BalanceFormResult `bson:"balance_result,omitempty" json:"balance_result,omitempty"`
+	RecipientID     int                       `bson:"recipient_id,omitempty" json:"recipient_id,omitempty"`
+	VisibleTo       []int                     `bson:"visible_to,omitempty" json:"visible_to,omitempty"`
+	Media           *models.MediaRef          `bson:"media,omitempty" json:"media,omitempty"`
+	ReplyTo         primitive.ObjectID        `bson:"reply_to,omitempty" json:"reply_to,omitempty"`
+	ReplyPreview    *models.ReplyPreview      `bson:"-" json:"reply_preview,omitempty"`
+	CreatedAt       time.Time                 `bson:"created_at" json:"created_at"`
 }
 
 type VoteCommentChatEvent struct {
@@ -51,6 +60,7 @@ type MatchEvent struct {
 	MatchId      string                   `bson:"match_id" json:"match_id"`
 	MatchType    int                      `bson:"match_type" json:"match_type"`
 	MatchedUsers []commontype.WaitingUser `bson:"matched_users" json:"matched_users"`
+	RevealPolicy *int                     `bson:"reveal_policy,omitempty" json:"reveal_policy,omitempty"` // 최종 선택 공개 정책, 없으면 FINAL_CHOICE_REVEAL_POLICY
 }
 
 type RoomLeaveEvent struct {
@@ -63,6 +73,26 @@ type RoomTimeoutEvent struct {
 	InactiveUserIds []int  `json:"inactive_user_ids"`
 }
 
+type RoomRemainTimeEvent struct {
+	RoomID           string `json:"room_id"`
+	Phase            string `json:"phase"` // chat, final_choice, couple
+	RemainingSeconds int    `json:"remaining_seconds"`
+	UserIDs          []int  `json:"user_ids,omitempty"` // 커플 채팅방 만료 알림 푸시 대상
+}
+
+type CoupleRoomCloseEvent struct {
+	RoomID   string    `json:"room_id"`
+	UserIDs  []int     `json:"user_ids"`
+	ClosedAt time.Time `json:"closed_at"`
+}
+
+// ChatMediaDeleteEvent - 보내기 취소된 이미지 메시지의 미디어 삭제 요청
+type ChatMediaDeleteEvent struct {
+	RoomID    string `json:"room_id"`
+	MediaID   string `json:"media_id"`
+	MessageID string `json:"message_id"`
+}
+
 type FinalChoiceTimeoutEvent struct {
 	RoomID  string `bson:"room_id" json:"room_id"`
 	UserIDs []int  `bson:"user_ids" json:"user_ids"`
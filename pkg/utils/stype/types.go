@@ -1,6 +1,10 @@
 package stype
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"solo/pkg/models"
+	"time"
+)
 
 type WebSocketMessage struct {
 	Kind    string          `json:"kind"`
@@ -22,7 +26,29 @@ const (
 	MessageKindFinalChoiceStart   = "final_choice_start"
 	MessageKindFinalChoice        = "final_choice"
 	MessageKindFinalChoiceResult  = "final_choice_result"
+	MessageKindFinalChoiceAck     = "final_choice_ack"
 	MessageKindCoupleMatchSuccess = "couple_match_success"
+	MessageKindModeration         = "moderation"
+	MessageKindActivityInput      = "activity_input"
+	MessageKindServerRestart      = "server_restart"
+	MessageKindRoomExtendPropose  = "room_extend_propose"
+	MessageKindRoomExtendVote     = "room_extend_vote"
+	MessageKindRoomExtendResult   = "room_extend_result"
+	MessageKindWhisper            = "whisper"
+	MessageKindWhisperAck         = "whisper_ack"
+	MessageKindReaction           = "reaction"
+	MessageKindImage              = "image"
+	MessageKindFirstImpression    = "first_impression"
+	MessageKindCoupleExtend       = "couple_extend"
+	MessageKindCoupleRoomClosed   = "couple_room_closed"
+	MessageKindContactExchange    = "contact_exchange"
+	MessageKindContactRevealed    = "contact_revealed"
+	MessageKindResume             = "resume"
+	MessageKindResumeResult       = "resume_result"
+	MessageKindMessageEdit        = "message_edit"
+	MessageKindMessageDelete      = "message_delete"
+	MessageKindMessageUpdated     = "message_updated"
+	MessageKindMessageUpdateAck   = "message_update_ack"
 )
 
 const (
@@ -38,11 +64,16 @@ type ChatMessage struct {
 	HeadCnt int    `json:"head_cnt"`
 	RoomID  string `json:"room_id"`
 	Message string `json:"message"`
+	ReplyTo string `json:"reply_to,omitempty"` // 답장 대상 메시지 ID
 }
 
 type FinalChoiceResultMessage struct {
-	RoomID  string       `json:"room_id"`
-	Choices []UserChoice `json:"choices"`
+	RoomID        string       `json:"room_id"`
+	RevealPolicy  int          `json:"reveal_policy"`
+	Choices       []UserChoice `json:"choices,omitempty"`         // 전체 공개
+	Matched       *bool        `json:"matched,omitempty"`         // 매칭 여부만 공개
+	MatchedUserID int          `json:"matched_user_id,omitempty"` // 매칭 여부만 공개
+	ReceivedCount *int         `json:"received_count,omitempty"`  // 받은 선택 수만 공개
 }
 
 type FinalChoiceStartMessage struct {
@@ -62,9 +93,136 @@ type RoomTimeoutMessage struct {
 	RoomID string `json:"room_id"`
 }
 
+type RoomRemainingMessage struct {
+	RoomID           string `json:"room_id"`
+	Phase            string `json:"phase"` // chat, final_choice, couple
+	RemainingSeconds int    `json:"remaining_seconds"`
+}
+
+// CoupleExtendMessage - 커플 채팅방 대화 연장 동의/철회
+type CoupleExtendMessage struct {
+	RoomID string `json:"room_id"`
+	Agree  bool   `json:"agree"`
+}
+
+// CoupleExtendStatusMessage - 커플 채팅방 연장 동의 현황 및 결과
+type CoupleExtendStatusMessage struct {
+	RoomID        string    `json:"room_id"`
+	AgreedUserIDs []int     `json:"agreed_user_ids"`
+	Required      int       `json:"required"`
+	Extended      bool      `json:"extended"`
+	FinishChatAt  time.Time `json:"finish_chat_at,omitempty"`
+	Reason        string    `json:"reason,omitempty"`
+}
+
+// CoupleRoomClosedMessage - 커플 채팅방 종료 알림
+type CoupleRoomClosedMessage struct {
+	RoomID   string    `json:"room_id"`
+	ClosedAt time.Time `json:"closed_at"`
+}
+
+// ResumeMessage - 앱 복귀 시 새 소켓에서 보내는 세션 복구 요청
+type ResumeMessage struct {
+	Rooms []ResumeRoom `json:"rooms"`
+}
+
+type ResumeRoom struct {
+	RoomID            string `json:"room_id"`
+	LastSeenMessageID string `json:"last_seen_message_id,omitempty"` // 비어있으면 최근 메시지부터
+	Foreground        bool   `json:"foreground,omitempty"`           // 화면에 열려 있는 방, 이 방만 재참여(읽음 처리)
+}
+
+// ResumeResultMessage - 방별 놓친 메시지와 현재 단계를 한 번에 응답
+type ResumeResultMessage struct {
+	Rooms []ResumeRoomResult `json:"rooms"`
+}
+
+type ResumeRoomResult struct {
+	RoomID           string         `json:"room_id"`
+	Messages         []*models.Chat `json:"messages"`
+	HasMore          bool           `json:"has_more"` // 놓친 메시지가 더 있으면 HTTP로 이어서 조회
+	Phase            string         `json:"phase,omitempty"`
+	RemainingSeconds int            `json:"remaining_seconds"`
+	Error            string         `json:"error,omitempty"`
+}
+
+// 연락처 교환 요청 종류
+const (
+	ContactExchangeSubmit  = "submit"
+	ContactExchangeConfirm = "confirm"
+	ContactExchangeCancel  = "cancel"
+)
+
+// ContactExchangeMessage - 커플 채팅방 연락처 제출/확인/취소
+type ContactExchangeMessage struct {
+	RoomID string `json:"room_id"`
+	Action string `json:"action"`           // submit, confirm, cancel
+	Handle string `json:"handle,omitempty"` // submit일 때만 사용
+}
+
+// ContactExchangeStatusMessage - 연락처 교환 진행 현황 (연락처 내용은 포함하지 않음)
+type ContactExchangeStatusMessage struct {
+	RoomID           string `json:"room_id"`
+	SubmittedUserIDs []int  `json:"submitted_user_ids"`
+	ConfirmedUserIDs []int  `json:"confirmed_user_ids"`
+	Required         int    `json:"required"`
+	CancelledBy      int    `json:"cancelled_by,omitempty"`
+	Reason           string `json:"reason,omitempty"`
+}
+
+// ContactRevealedMessage - 양쪽 모두 확인 후 공개된 연락처
+type ContactRevealedMessage struct {
+	RoomID   string          `json:"room_id"`
+	Contacts []ContactHandle `json:"contacts"`
+}
+
+type ContactHandle struct {
+	UserID int    `json:"user_id"`
+	Handle string `json:"handle"`
+}
+
+type RoomExtendProposeMessage struct {
+	RoomID string `json:"room_id"`
+}
+
+type RoomExtendVoteMessage struct {
+	RoomID string `json:"room_id"`
+	Agree  bool   `json:"agree"`
+}
+
+// RoomExtendVoteStatusMessage - 연장 투표 진행 현황
+type RoomExtendVoteStatusMessage struct {
+	RoomID     string    `json:"room_id"`
+	ProposerID int       `json:"proposer_id,omitempty"`
+	AgreeCount int       `json:"agree_count"`
+	DenyCount  int       `json:"deny_count"`
+	Required   int       `json:"required"`
+	PointCost  int       `json:"point_cost"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+type RoomExtendResultMessage struct {
+	RoomID              string    `json:"room_id"`
+	Extended            bool      `json:"extended"`
+	Reason              string    `json:"reason,omitempty"`
+	FinishChatAt        time.Time `json:"finish_chat_at"`
+	FinishFinalChoiceAt time.Time `json:"finish_final_choice_at"`
+	RemainingSeconds    int       `json:"remaining_seconds"`
+}
+
 type FinalChoiceMessage struct {
 	RoomID         string `json:"room_id"`
 	SelectedUserID int    `json:"selected_user_id"`
+	Withdraw       bool   `json:"withdraw"` // true면 기존 선택 철회
+}
+
+type FinalChoiceAckMessage struct {
+	RoomID                 string `json:"room_id"`
+	Accepted               bool   `json:"accepted"`
+	SelectedUserID         int    `json:"selected_user_id"`
+	PreviousSelectedUserID int    `json:"previous_selected_user_id,omitempty"`
+	Withdrawn              bool   `json:"withdrawn"`
+	Reason                 string `json:"reason,omitempty"`
 }
 
 type UserChoice struct {
@@ -75,3 +233,102 @@ type UserChoice struct {
 type CoupleMatchSuccessMessage struct {
 	RoomID string `json:"room_id"`
 }
+
+type ServerRestartMessage struct {
+	ReconnectAfterMs int `json:"reconnect_after_ms"` // 재연결 전 대기 시간 (클라이언트 동시 재접속 분산)
+}
+
+// ImageMessage - 업로드한 이미지를 채팅으로 전송
+type ImageMessage struct {
+	RoomID  string `json:"room_id"`
+	MediaID string `json:"media_id"`
+}
+
+// MessageEditMessage - 보낸 메시지 수정 요청
+type MessageEditMessage struct {
+	RoomID    string `json:"room_id"`
+	MessageID string `json:"message_id"`
+	Message   string `json:"message"`
+}
+
+// MessageDeleteMessage - 보낸 메시지 보내기 취소 요청
+type MessageDeleteMessage struct {
+	RoomID    string `json:"room_id"`
+	MessageID string `json:"message_id"`
+}
+
+// MessageUpdatedMessage - 방에 전송되는 메시지 수정/삭제 알림
+type MessageUpdatedMessage struct {
+	RoomID    string     `json:"room_id"`
+	MessageID string     `json:"message_id"`
+	Action    string     `json:"action"` // edit, delete
+	Message   string     `json:"message"`
+	EditedAt  *time.Time `json:"edited_at,omitempty"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+// MessageUpdateAckMessage - 수정/삭제 요청 결과 (요청자에게만 전송)
+type MessageUpdateAckMessage struct {
+	RoomID    string `json:"room_id"`
+	MessageID string `json:"message_id"`
+	Accepted  bool   `json:"accepted"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+// FirstImpressionMessage - 첫인상 선택 라운드 진행 알림
+// start: 선택 시작, ack: 내 선택 결과, finish: 내가 받은 선택 수 (누가 선택했는지는 공개하지 않음)
+type FirstImpressionMessage struct {
+	RoomID         string    `json:"room_id"`
+	InstanceID     string    `json:"instance_id"`
+	Phase          string    `json:"phase"`
+	EndsAt         time.Time `json:"ends_at,omitempty"`
+	SelectedUserID int       `json:"selected_user_id,omitempty"`
+	ReceivedCount  int       `json:"received_count"`
+	Accepted       bool      `json:"accepted"`
+	Reason         string    `json:"reason,omitempty"`
+}
+
+type WhisperMessage struct {
+	RoomID      string `json:"room_id"`
+	RecipientID int    `json:"recipient_id"`
+	Message     string `json:"message"`
+}
+
+type WhisperAckMessage struct {
+	RoomID    string `json:"room_id"`
+	Accepted  bool   `json:"accepted"`
+	Remaining int    `json:"remaining"` // 현재 구간에서 남은 귓속말 횟수
+	Reason    string `json:"reason,omitempty"`
+}
+
+// ReactionMessage - 리액션 추가/제거 요청
+type ReactionMessage struct {
+	RoomID    string `json:"room_id"`
+	MessageID string `json:"message_id"`
+	Reaction  string `json:"reaction"`
+	Remove    bool   `json:"remove"`
+}
+
+// ReactionDeltaMessage - 방에 전송되는 리액션 변경분
+type ReactionDeltaMessage struct {
+	RoomID    string `json:"room_id"`
+	MessageID string `json:"message_id"`
+	UserID    int    `json:"user_id"`
+	Reaction  string `json:"reaction"`
+	Action    string `json:"action"` // add, remove
+	Count     int    `json:"count"`  // 변경 후 해당 리액션 개수
+}
+
+type ModerationMessage struct {
+	RoomID string `json:"room_id"`
+	Action string `json:"action"` // mask, reject
+	Stage  string `json:"stage"`
+	Reason string `json:"reason"`
+}
+
+type ActivityInputMessage struct {
+	RoomID     string          `json:"room_id"`
+	Activity   string          `json:"activity"`
+	InstanceID string          `json:"instance_id"`
+	Data       json.RawMessage `json:"data"`
+}
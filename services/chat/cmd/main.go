@@ -10,6 +10,7 @@ import (
 
 	"solo/pkg/db"
 	"solo/pkg/logger"
+	"solo/pkg/media"
 	"solo/pkg/mq"
 	"solo/pkg/redis"
 	"solo/services/chat/event"
@@ -64,12 +65,33 @@ func main() {
 		log.Panic("Failed to User DB Migration: ", err)
 	}
 
-	chatService := service.NewChatService(chatRepo, userRepo, redisClient, emitter) // Service 생성
-	chatHandler := handler.NewChatHandler(chatService)                              // Handler 생성
+	mediaStore, err := media.NewStoreFromConfig()
+	if err != nil {
+		log.Panic("Media Store 생성 실패: ", err)
+	}
+
+	chatService := service.NewChatService(chatRepo, userRepo, redisClient, emitter, mediaStore) // Service 생성
+	chatHandler := handler.NewChatHandler(chatService)                                          // Handler 생성
 
 	eventConsumer := event.NewConsumer(mqClient, redisClient, chatService)
 	go eventConsumer.StartListening()
 
+	// 메시지 페이지네이션/검색 인덱스 생성 (기존 메시지가 많으면 오래 걸리므로 시작과 분리)
+	go func() {
+		if err := chatRepo.EnsureMessageIndexes(); err != nil {
+			log.Printf("❌ Failed to create message indexes: %v", err)
+		}
+	}()
+
+	// 검색 토큰이 없는 기존 메시지 보강 (한 파드에서만 실행, 완료 후에는 건너뜀)
+	go chatService.BackfillSearchTokens()
+
+	// 예약된 방 데이터 정리 작업 실행 (재시작 전 밀린 작업 포함)
+	go chatService.RunCleanupWorker()
+
+	// 보관 기간이 지난 방 데이터 삭제
+	go chatService.RunArchivePurgeWorker()
+
 	router := transport.NewRouter(chatHandler, chatService)
 
 	log.Printf("🚀 Chat Service Started on Port %d", webPort)
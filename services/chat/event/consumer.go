@@ -42,6 +42,8 @@ func (c *Consumer) StartListening() {
 			mq.RoutingKeyRoomTimeout,
 			mq.RoutingKeyFinalChoiceTimeout,
 			mq.RoutingKeyRoomJoin,
+			mq.RoutingKeyCoupleRoomClose,
+			mq.RoutingKeyChatMediaDelete,
 		})
 	if err != nil {
 		log.Fatalf("❌ Failed to declare queue %s for %s: %v", mq.QueueChat, mq.ExchangeAppTopic, err)
@@ -65,10 +67,12 @@ func (c *Consumer) StartListening() {
 		mq.EventTypeRoomTimeout:        c.eventHandler.HandleRoomTimeout,
 		mq.EventTypeFinalChoiceTimeout: c.eventHandler.HandleFinalChoiceTimeout,
 		mq.EventTypeRoomJoin:           c.eventHandler.HandleRoomJoin,
+		mq.EventTypeCoupleRoomClose:    c.eventHandler.HandleCoupleRoomClose,
+		mq.EventTypeChatMediaDelete:    c.eventHandler.HandleChatMediaDelete,
 	}
 
-	// 메시지 소비 시작
-	c.mqClient.ConsumeMessages(queue.Name, handlers)
+	// 메시지 소비 시작 (같은 방의 이벤트는 순서대로 처리)
+	c.mqClient.ConsumeMessagesWithMode(queue.Name, handlers, mq.DispatchPerRoom)
 
 	log.Println("✅ RabbitMQ Consumer Listening...")
 }
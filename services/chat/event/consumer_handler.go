@@ -2,13 +2,15 @@ package event
 
 import (
 	"encoding/json"
+	"fmt"
+	"solo/pkg/config"
+	"solo/pkg/logger"
 	"solo/pkg/models"
 	"solo/pkg/redis"
 	"solo/pkg/types/commontype"
 	eventtypes "solo/pkg/types/eventtype"
 	"solo/pkg/utils/printer"
 	"solo/services/chat/service"
-	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
@@ -40,6 +42,11 @@ func (e *EventHandler) HandleChatEvent(body json.RawMessage) {
 		UnreadCount:   chatEvent.UnreadCount,
 		CreatedAt:     chatEvent.CreatedAt,
 		BalanceFormID: chatEvent.BalanceFormID,
+		BalanceResult: chatEvent.BalanceResult,
+		RecipientID:   chatEvent.RecipientID,
+		VisibleTo:     chatEvent.VisibleTo,
+		Media:         chatEvent.Media,
+		ReplyTo:       chatEvent.ReplyTo,
 	}
 
 	_, err := e.chatService.AddChatMsg(chat)
@@ -98,100 +105,95 @@ func (e *EventHandler) HandleRoomTimeout(body json.RawMessage) {
 	}
 }
 
-func (e *EventHandler) cleanupRoomData(roomID string) {
-	room, err := e.chatService.GetChatRoomByID(roomID)
-	if err != nil {
-		printer.PrintError("Failed to get room status", err)
+func (e *EventHandler) HandleFinalChoiceTimeout(body json.RawMessage) {
+	var eventData eventtypes.FinalChoiceTimeoutEvent
+	if err := json.Unmarshal(body, &eventData); err != nil {
+		printer.PrintError("Failed to unmarshal final choice timeout event", err)
 		return
 	}
 
-	if room.Status != commontype.RoomStatusGameEnd {
-		printer.PrintError("Room is not in GameEnd status", nil)
-		return
-	}
+	printer.PrintFinalChoiceTimeoutEvent(eventData)
 
-	balanceForms, err := e.chatService.GetBalanceFormsByRoomID(roomID)
+	err := e.chatService.UpdateChatRoomStatus(eventData.RoomID, commontype.RoomStatusChoiceComplete)
 	if err != nil {
-		printer.PrintError("Failed to get balance forms", err)
-		return
+		printer.PrintError("Failed to update chat room status", err)
 	}
 
-	for _, form := range balanceForms {
-		err = e.chatService.DeleteBalanceFormVotes(form.ID)
-		if err != nil {
-			printer.PrintError("Failed to delete balance form votes", err)
-		}
-
-		err = e.chatService.DeleteBalanceFormComments(form.ID)
-		if err != nil {
-			printer.PrintError("Failed to delete balance form comments", err)
-		}
+	err = e.redisClient.RemoveChoiceRoomFromRedis(eventData.RoomID)
+	if err != nil {
+		printer.PrintError("Failed to remove RoomID from rooms list", err)
 	}
 
-	err = e.chatService.DeleteBalanceFormsByRoomID(roomID)
+	err = e.chatService.UpdateChatRoomStatus(eventData.RoomID, commontype.RoomStatusGameEnd)
 	if err != nil {
-		printer.PrintError("Failed to delete balance forms", err)
+		printer.PrintError("Failed to update chat room status", err)
 	}
 
-	err = e.chatService.DeleteMessageReaders(roomID)
+	err = e.chatService.ScheduleRoomCleanup(eventData.RoomID, commontype.RemoveRoomDataTimer)
 	if err != nil {
-		printer.PrintError("Failed to delete message readers", err)
+		printer.PrintError("Failed to schedule room cleanup", err)
 	}
+}
 
-	err = e.chatService.DeleteChatByRoomID(roomID)
-	if err != nil {
-		printer.PrintError("Failed to delete messages", err)
+func (e *EventHandler) HandleRoomJoin(body json.RawMessage) {
+	var eventData eventtypes.RoomJoinEvent
+	if err := json.Unmarshal(body, &eventData); err != nil {
+		printer.PrintError("Failed to unmarshal room join event", err)
+		return
 	}
 
-	err = e.chatService.DeleteChatRoom(roomID)
+	printer.PrintRoomJoinEvent(eventData)
+
+	err := e.chatService.HandleRoomJoin(eventData.RoomID, eventData.UserID, eventData.JoinAt)
 	if err != nil {
-		printer.PrintError("Failed to delete room", err)
+		printer.PrintError("Failed to room join", err)
 	}
-
-	printer.PrintSuccess("Successfully cleaned up all data for room " + roomID)
 }
 
-func (e *EventHandler) HandleFinalChoiceTimeout(body json.RawMessage) {
-	var eventData eventtypes.FinalChoiceTimeoutEvent
+func (e *EventHandler) HandleCoupleRoomClose(body json.RawMessage) {
+	var eventData eventtypes.CoupleRoomCloseEvent
 	if err := json.Unmarshal(body, &eventData); err != nil {
-		printer.PrintError("Failed to unmarshal final choice timeout event", err)
+		printer.PrintError("Failed to unmarshal couple room close event", err)
 		return
 	}
 
-	printer.PrintFinalChoiceTimeoutEvent(eventData)
+	printer.PrintSuccess("Closing couple room " + eventData.RoomID)
 
-	err := e.chatService.UpdateChatRoomStatus(eventData.RoomID, commontype.RoomStatusChoiceComplete)
+	err := e.chatService.UpdateChatRoomStatus(eventData.RoomID, commontype.RoomStatusGameEnd)
 	if err != nil {
 		printer.PrintError("Failed to update chat room status", err)
 	}
 
-	err = e.redisClient.RemoveChoiceRoomFromRedis(eventData.RoomID)
-	if err != nil {
-		printer.PrintError("Failed to remove RoomID from rooms list", err)
-	}
+	logger.Info(logger.LogEventCoupleRoomTimeout, fmt.Sprintf("Couple room closed: %s", eventData.RoomID), eventData)
 
-	err = e.chatService.UpdateChatRoomStatus(eventData.RoomID, commontype.RoomStatusGameEnd)
+	// 종료 후 보관 기간 동안은 대화 내용을 읽을 수 있도록 유지
+	err = e.chatService.ScheduleRoomCleanup(eventData.RoomID, config.GetDuration("COUPLE_ROOM_RETENTION", commontype.CoupleRoomRetention))
 	if err != nil {
-		printer.PrintError("Failed to update chat room status", err)
+		printer.PrintError("Failed to schedule room cleanup", err)
 	}
-
-	go func() {
-		time.Sleep(commontype.RemoveRoomDataTimer)
-		e.cleanupRoomData(eventData.RoomID)
-	}()
 }
 
-func (e *EventHandler) HandleRoomJoin(body json.RawMessage) {
-	var eventData eventtypes.RoomJoinEvent
+func (e *EventHandler) HandleChatMediaDelete(body json.RawMessage) {
+	var eventData eventtypes.ChatMediaDeleteEvent
 	if err := json.Unmarshal(body, &eventData); err != nil {
-		printer.PrintError("Failed to unmarshal room join event", err)
+		printer.PrintError("Failed to unmarshal chat media delete event", err)
 		return
 	}
 
-	printer.PrintRoomJoinEvent(eventData)
+	mediaID, err := primitive.ObjectIDFromHex(eventData.MediaID)
+	if err != nil {
+		printer.PrintError("Invalid media ID in chat media delete event", err)
+		return
+	}
 
-	err := e.chatService.HandleRoomJoin(eventData.RoomID, eventData.UserID, eventData.JoinAt)
+	messageID, err := primitive.ObjectIDFromHex(eventData.MessageID)
 	if err != nil {
-		printer.PrintError("Failed to room join", err)
+		printer.PrintError("Invalid message ID in chat media delete event", err)
+		return
+	}
+
+	err = e.chatService.DeleteChatMedia(eventData.RoomID, mediaID, messageID)
+	if err != nil {
+		printer.PrintError("Failed to delete chat media", err)
 	}
 }
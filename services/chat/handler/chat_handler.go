@@ -1,13 +1,18 @@
 package handler
 
 import (
+	"errors"
+	"fmt"
 	"log"
 	"math"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"solo/pkg/dto"
+	"solo/pkg/media"
 	"solo/pkg/models"
 	"solo/pkg/types/commontype"
 	"solo/services/chat/service"
@@ -44,46 +49,38 @@ func (h *ChatHandler) GetChatRoomList(c echo.Context) error {
 		return err
 	}
 
-	rooms, err := h.chatService.GetChatRoomList(userID)
+	rooms, err := h.chatService.GetChatRoomSummaries(userID)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to retrieve chat rooms"})
 	}
 
 	var roomlist []dto.RoomListResponse
 	for _, room := range rooms {
-		latestMessage, err := h.chatService.GetLatestMessage(room.ID)
-		if err != nil {
-			return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to retrieve latest message"})
+		// 마지막 메시지나 캐릭터 정보가 없는 방은 빈 값으로 표시
+		lastMessage := dto.LastMessage{}
+		if room.LastMessage != nil {
+			lastMessage.SenderID = room.LastMessage.SenderID
+			lastMessage.Message = room.LastMessage.Message
+			lastMessage.CreatedAt = room.LastMessage.CreatedAt
+			lastMessage.EditedAt = room.LastMessage.EditedAt
+			lastMessage.Deleted = room.LastMessage.DeletedAt != nil
 		}
-
-		unreadCount, err := h.chatService.GetUnreadCount(room.ID, userID)
-		if err != nil {
-			return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to retrieve unread count"})
-		}
-
-		gamerInfo, err := h.chatService.GetGamerInfo(userID, room.ID)
-		if err != nil {
-			return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to retrieve gamer info"})
-		}
-		if gamerInfo == nil {
-			return c.JSON(http.StatusNotFound, map[string]string{"error": "Gamer info not found"})
+		if room.Gamer != nil {
+			lastMessage.GameInfo = commontype.GameInfo{
+				CharacterID:        room.Gamer.CharacterID,
+				CharacterName:      room.Gamer.CharacterName,
+				CharacterAvatarURL: room.Gamer.CharacterAvatarURL,
+			}
+		} else {
+			log.Printf("⚠️ Gamer info not found for user %d in room %s", userID, room.ID)
 		}
 
 		roomlist = append(roomlist, dto.RoomListResponse{
-			ID:       room.ID,
-			RoomName: room.Name,
-			RoomType: room.Type,
-			LastMessage: dto.LastMessage{
-				SenderID: latestMessage.SenderID,
-				Message:  latestMessage.Message,
-				GameInfo: commontype.GameInfo{
-					CharacterID:        gamerInfo.CharacterID,
-					CharacterName:      gamerInfo.CharacterName,
-					CharacterAvatarURL: gamerInfo.CharacterAvatarURL,
-				},
-				CreatedAt: latestMessage.CreatedAt,
-			},
-			UnreadCount: unreadCount,
+			ID:          room.ID,
+			RoomName:    room.Name,
+			RoomType:    room.Type,
+			LastMessage: lastMessage,
+			UnreadCount: room.UnreadCount,
 			CreatedAt:   room.CreatedAt,
 			ModifiedAt:  room.ModifiedAt,
 		})
@@ -140,13 +137,25 @@ func (h *ChatHandler) DeleteChatRoom(c echo.Context) error {
 func (h *ChatHandler) GetChatMsgListByRoomID(c echo.Context) error {
 	roomID := c.Param("id")
 
+	userID, err := getUserID(c)
+	if err != nil {
+		return err
+	}
+
+	before := c.QueryParam("before")
+	after := c.QueryParam("after")
+	if before != "" || after != "" {
+		return h.getChatMsgListByCursor(c, roomID, userID, before, after)
+	}
+
+	// 기존 클라이언트를 위한 페이지 번호 방식
 	pageStr := c.QueryParam("page")
 	page, err := strconv.Atoi(pageStr)
 	if err != nil || page < 1 {
 		page = 1
 	}
 
-	messages, totalCount, err := h.chatService.GetChatMsgListByRoomID(roomID, page, commontype.DEFAULT_PAGE_SIZE)
+	messages, totalCount, err := h.chatService.GetChatMsgListByRoomID(roomID, userID, page, commontype.DEFAULT_PAGE_SIZE)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to fetch chat messages"})
 	}
@@ -158,10 +167,175 @@ func (h *ChatHandler) GetChatMsgListByRoomID(c echo.Context) error {
 		HasNextPage: page < int(totalCount),
 		TotalPages:  int(math.Ceil(float64(totalCount) / float64(commontype.DEFAULT_PAGE_SIZE))),
 	}
+	// 첫 페이지 이후로는 커서 방식으로 이어서 조회할 수 있도록 커서 제공
+	if len(messages) > 0 {
+		response.NextCursor = messages[len(messages)-1].MessageId.Hex()
+	}
 
 	return c.JSON(http.StatusOK, response)
 }
 
+// 커서(before/after 메시지 ID) 방식 메시지 목록 조회
+func (h *ChatHandler) getChatMsgListByCursor(c echo.Context, roomID string, userID int, before, after string) error {
+	if before != "" && after != "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Use either before or after, not both"})
+	}
+
+	direction, cursor := service.CursorBefore, before
+	if after != "" {
+		direction, cursor = service.CursorAfter, after
+	}
+
+	cursorID, err := primitive.ObjectIDFromHex(cursor)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid cursor"})
+	}
+
+	messages, hasMore, err := h.chatService.GetChatMsgListByCursor(roomID, userID, cursorID, direction, commontype.DEFAULT_PAGE_SIZE)
+	if errors.Is(err, service.ErrCursorNotFound) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Cursor message not found"})
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to fetch chat messages"})
+	}
+
+	// 메시지는 최신순, 첫 메시지가 가장 새롭고 마지막 메시지가 가장 오래됨
+	response := dto.ChatListResponse{Data: messages}
+	hasOlder := direction == service.CursorAfter || hasMore
+	hasNewer := direction == service.CursorBefore || hasMore
+
+	if hasOlder {
+		response.NextCursor = cursor
+		if len(messages) > 0 {
+			response.NextCursor = messages[len(messages)-1].MessageId.Hex()
+		}
+	}
+	if hasNewer {
+		response.PrevCursor = cursor
+		if len(messages) > 0 {
+			response.PrevCursor = messages[0].MessageId.Hex()
+		}
+	}
+	response.HasNextPage = response.NextCursor != ""
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// 채팅방 메시지 검색
+func (h *ChatHandler) SearchChatMessages(c echo.Context) error {
+	roomID := c.Param("id")
+
+	userID, err := getUserID(c)
+	if err != nil {
+		return err
+	}
+
+	query := strings.TrimSpace(c.QueryParam("q"))
+	if query == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Search query is required"})
+	}
+	if utf8.RuneCountInString(query) > commontype.SearchQueryMaxLen {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Search query is too long"})
+	}
+
+	var beforeID primitive.ObjectID
+	if before := c.QueryParam("before"); before != "" {
+		beforeID, err = primitive.ObjectIDFromHex(before)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid cursor"})
+		}
+	}
+
+	hits, hasMore, err := h.chatService.SearchChatMessages(roomID, userID, query, beforeID)
+	if errors.Is(err, service.ErrCursorNotFound) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Cursor message not found"})
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to search chat messages"})
+	}
+
+	response := dto.ChatSearchResponse{Data: hits, HasNextPage: hasMore}
+	if hasMore {
+		response.NextCursor = hits[len(hits)-1].Message.MessageId.Hex()
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// 채팅방 대화 내보내기 (format=json|txt)
+func (h *ChatHandler) ExportChatTranscript(c echo.Context) error {
+	roomID := c.Param("id")
+
+	userID, err := getUserID(c)
+	if err != nil {
+		return err
+	}
+
+	format := c.QueryParam("format")
+	if format == "" {
+		format = service.TranscriptFormatJSON
+	}
+
+	contentType := map[string]string{
+		service.TranscriptFormatJSON: echo.MIMEApplicationJSONCharsetUTF8,
+		service.TranscriptFormatText: echo.MIMETextPlainCharsetUTF8,
+	}[format]
+	if contentType == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Unsupported format"})
+	}
+
+	transcript, err := h.chatService.NewTranscript(roomID, userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to export chat transcript"})
+	}
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, contentType)
+	res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, transcript.FileName(format)))
+	res.WriteHeader(http.StatusOK)
+
+	// 스트리밍 중 실패하면 상태 코드를 바꿀 수 없으므로 로그만 남김
+	if err := transcript.Write(format, res); err != nil {
+		log.Printf("Failed to stream chat transcript for room %s, user %d: %v", roomID, userID, err)
+	}
+
+	return nil
+}
+
+// 보관된 방 데이터 조회 (관리자 전용), /admin/archive/:id 또는 /admin/archive/seq/:seq
+func (h *ChatHandler) GetRoomArchive(c echo.Context) error {
+	var archive *models.RoomArchive
+	var err error
+
+	if seqStr := c.Param("seq"); seqStr != "" {
+		seq, convErr := strconv.ParseInt(seqStr, 10, 64)
+		if convErr != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid room seq"})
+		}
+		archive, err = h.chatService.GetRoomArchiveBySeq(seq)
+	} else {
+		archive, err = h.chatService.GetRoomArchive(c.Param("id"))
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to find room archive"})
+	}
+	if archive == nil {
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "Room archive not found"})
+	}
+
+	reader, err := h.chatService.OpenRoomArchive(c.Request().Context(), archive)
+	if err != nil {
+		log.Printf("Failed to open room archive %s: %v", archive.RoomID, err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to open room archive"})
+	}
+	defer reader.Close()
+
+	log.Printf("🔐 Room archive %s accessed by admin %s", archive.RoomID, c.Request().Header.Get("X-User-ID"))
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="archive-%d.json"`, archive.RoomSeq))
+	return c.Stream(http.StatusOK, echo.MIMEApplicationJSONCharsetUTF8, reader)
+}
+
 // 특정 채팅방의 메시지 삭제
 func (h *ChatHandler) DeleteChatByRoomID(c echo.Context) error {
 	roomID := c.Param("id")
@@ -228,6 +402,10 @@ func (h *ChatHandler) InsertBalanceFormVote(c echo.Context) error {
 		return err
 	}
 
+	if ok, err := h.checkBalanceFormAccess(c, userID, objectID); !ok {
+		return err
+	}
+
 	var voteDTO dto.BalanceFormVoteDTO
 	if err := c.Bind(&voteDTO); err != nil {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request body"})
@@ -248,6 +426,12 @@ func (h *ChatHandler) InsertBalanceFormVote(c echo.Context) error {
 				"message": "이미 투표한 사용자입니다",
 			})
 		}
+		if err.Error() == "balance form finished" {
+			return c.JSON(http.StatusConflict, map[string]string{
+				"error":   "balance form finished",
+				"message": "종료된 밸런스 게임입니다",
+			})
+		}
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to insert balance form vote"})
 	}
 
@@ -267,14 +451,36 @@ func (h *ChatHandler) CancelBalanceFormVote(c echo.Context) error {
 		return err
 	}
 
+	if ok, err := h.checkBalanceFormAccess(c, userID, objectID); !ok {
+		return err
+	}
+
 	err = h.chatService.CancelBalanceFormVote(objectID, userID)
 	if err != nil {
+		if err.Error() == "balance form finished" {
+			return c.JSON(http.StatusConflict, map[string]string{
+				"error":   "balance form finished",
+				"message": "종료된 밸런스 게임입니다",
+			})
+		}
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to cancel balance form vote"})
 	}
 
 	return c.JSON(http.StatusOK, map[string]string{"message": "Balance form vote canceled successfully"})
 }
 
+// 밸런스 게임 폼이 속한 방의 참가자인지 확인, 아니면 응답을 쓰고 false 반환
+func (h *ChatHandler) checkBalanceFormAccess(c echo.Context, userID int, formID primitive.ObjectID) (bool, error) {
+	hasAccess, err := h.chatService.IsUserInBalanceFormRoom(userID, formID)
+	if err != nil {
+		return false, c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to check room access"})
+	}
+	if !hasAccess {
+		return false, c.JSON(http.StatusForbidden, map[string]string{"error": "You don't have access to this room"})
+	}
+	return true, nil
+}
+
 // 밸런스 게임 투표 댓글 삽입
 func (h *ChatHandler) InsertBalanceFormComment(c echo.Context) error {
 	formID := c.Param("formid")
@@ -340,3 +546,72 @@ func (h *ChatHandler) GetBalanceFormComments(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, response)
 }
+
+// 채팅 이미지 업로드 (multipart "file")
+func (h *ChatHandler) UploadChatMedia(c echo.Context) error {
+	roomID := c.Param("id")
+
+	userID, err := getUserID(c)
+	if err != nil {
+		return err
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "File is required"})
+	}
+	if fileHeader.Size > service.MaxMediaUploadBytes() {
+		return c.JSON(http.StatusRequestEntityTooLarge, map[string]string{"error": "File is too large"})
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Failed to read file"})
+	}
+	defer file.Close()
+
+	chatMedia, err := h.chatService.UploadChatMedia(roomID, userID, file)
+	switch {
+	case errors.Is(err, service.ErrMediaNotAllowed):
+		return c.JSON(http.StatusForbidden, map[string]string{"error": "Images are not allowed in this room now"})
+	case errors.Is(err, media.ErrTooLarge):
+		return c.JSON(http.StatusRequestEntityTooLarge, map[string]string{"error": "File is too large"})
+	case errors.Is(err, media.ErrUnsupportedType):
+		return c.JSON(http.StatusUnsupportedMediaType, map[string]string{"error": "Only JPEG, PNG and GIF images are supported"})
+	case err != nil:
+		log.Printf("Failed to upload chat media: %v", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to upload media"})
+	}
+
+	return c.JSON(http.StatusOK, dto.ChatMediaResponse{
+		ChatMedia:    *chatMedia,
+		URL:          media.URL(roomID, chatMedia.ID.Hex(), false),
+		ThumbnailURL: media.URL(roomID, chatMedia.ID.Hex(), true),
+	})
+}
+
+// 채팅 이미지 조회 (thumb=true면 썸네일)
+func (h *ChatHandler) GetChatMedia(c echo.Context) error {
+	roomID := c.Param("id")
+
+	mediaID, err := primitive.ObjectIDFromHex(c.Param("mediaid"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid media ID format"})
+	}
+
+	thumbnail := c.QueryParam("thumb") == "true"
+
+	reader, contentType, err := h.chatService.OpenChatMedia(c.Request().Context(), roomID, mediaID, thumbnail)
+	if errors.Is(err, media.ErrNotFound) {
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "Media not found"})
+	}
+	if err != nil {
+		log.Printf("Failed to open chat media %s: %v", mediaID.Hex(), err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to retrieve media"})
+	}
+	defer reader.Close()
+
+	// 미디어는 변경되지 않으므로 브라우저 캐시 허용
+	c.Response().Header().Set("Cache-Control", "private, max-age=86400")
+	return c.Stream(http.StatusOK, contentType, reader)
+}
@@ -0,0 +1,188 @@
+package repo
+
+import (
+	"context"
+	"io"
+	"log"
+	"time"
+
+	"solo/pkg/models"
+
+	"github.com/samber/lo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// WriteRoomArchiveJSON - 방, 밸런스 게임 폼/투표/댓글, 메시지, 미디어 원본 문서를 하나의 JSON 객체로 w에 스트리밍
+// 방 문서가 이미 없으면 room은 null로 두고 남은 데이터만 보관
+// 수정 이력 등 API 응답에서 빠지는 필드도 남도록 Extended JSON(relaxed)으로 저장, 메시지 수 반환
+func (r *ChatRepository) WriteRoomArchiveJSON(roomID string, w io.Writer) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	db := r.client.Database("chat_db")
+
+	room, err := db.Collection("rooms").FindOne(ctx, bson.M{"id": roomID}).Raw()
+	if err != nil && err != mongo.ErrNoDocuments {
+		log.Printf("Error finding room %s for archive: %v", roomID, err)
+		return 0, err
+	}
+
+	forms, err := r.GetBalanceFormsByRoomID(roomID)
+	if err != nil {
+		return 0, err
+	}
+	formIDs := lo.Map(forms, func(f models.BalanceGameForm, _ int) primitive.ObjectID { return f.ID })
+
+	sections := []struct {
+		name       string
+		collection string
+		filter     bson.M
+	}{
+		{"balance_forms", "balance_forms", bson.M{"room_id": roomID}},
+		{"balance_form_votes", "balance_form_votes", bson.M{"form_id": bson.M{"$in": formIDs}}},
+		{"balance_form_comments", "balance_form_comments", bson.M{"balance_form_id": bson.M{"$in": formIDs}}},
+		{"messages", "messages", bson.M{"room_id": roomID}},
+		{"chat_media", "chat_media", bson.M{"room_id": roomID}},
+	}
+
+	if room == nil {
+		if _, err := io.WriteString(w, `{"room":null`); err != nil {
+			return 0, err
+		}
+	} else if err := writeExtJSON(w, `{"room":`, room); err != nil {
+		return 0, err
+	}
+
+	messageCount := 0
+	for _, section := range sections {
+		if _, err := io.WriteString(w, `,"`+section.name+`":[`); err != nil {
+			return 0, err
+		}
+
+		opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}).SetBatchSize(200)
+		cursor, err := db.Collection(section.collection).Find(ctx, section.filter, opts)
+		if err != nil {
+			log.Printf("Error finding %s for archive of room %s: %v", section.name, roomID, err)
+			return 0, err
+		}
+
+		prefix := ""
+		for cursor.Next(ctx) {
+			if err := writeExtJSON(w, prefix, cursor.Current); err != nil {
+				cursor.Close(ctx)
+				return 0, err
+			}
+			prefix = ","
+			if section.name == "messages" {
+				messageCount++
+			}
+		}
+		err = cursor.Err()
+		cursor.Close(ctx)
+		if err != nil {
+			return 0, err
+		}
+
+		if _, err := io.WriteString(w, "]"); err != nil {
+			return 0, err
+		}
+	}
+
+	_, err = io.WriteString(w, "}")
+	return messageCount, err
+}
+
+func writeExtJSON(w io.Writer, prefix string, doc bson.Raw) error {
+	data, err := bson.MarshalExtJSON(doc, false, false)
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(w, prefix); err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// UpsertRoomArchive - 방 보관 데이터 저장 (같은 방을 다시 보관하면 덮어씀)
+func (r *ChatRepository) UpsertRoomArchive(archive *models.RoomArchive) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	collection := r.client.Database("chat_db").Collection("room_archives")
+	_, err := collection.ReplaceOne(ctx, bson.M{"room_id": archive.RoomID}, archive, options.Replace().SetUpsert(true))
+	if err != nil {
+		log.Printf("Error saving archive for room %s: %v", archive.RoomID, err)
+		return err
+	}
+
+	return nil
+}
+
+// GetRoomArchiveByRoomID - 방 ID로 보관 데이터 조회, 없으면 nil
+func (r *ChatRepository) GetRoomArchiveByRoomID(roomID string) (*models.RoomArchive, error) {
+	return r.findRoomArchive(bson.M{"room_id": roomID})
+}
+
+// GetRoomArchiveBySeq - 방 Seq로 보관 데이터 조회, 없으면 nil
+func (r *ChatRepository) GetRoomArchiveBySeq(seq int64) (*models.RoomArchive, error) {
+	return r.findRoomArchive(bson.M{"room_seq": seq})
+}
+
+func (r *ChatRepository) findRoomArchive(filter bson.M) (*models.RoomArchive, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	collection := r.client.Database("chat_db").Collection("room_archives")
+
+	var archive models.RoomArchive
+	err := collection.FindOne(ctx, filter).Decode(&archive)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		log.Printf("Error finding room archive: %v", err)
+		return nil, err
+	}
+
+	return &archive, nil
+}
+
+// GetExpiredRoomArchives - 보관 기간이 지난 보관 데이터 목록 (압축 데이터 제외)
+func (r *ChatRepository) GetExpiredRoomArchives(now time.Time, limit int) ([]models.RoomArchive, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	collection := r.client.Database("chat_db").Collection("room_archives")
+	opts := options.Find().
+		SetSort(bson.D{{Key: "expire_at", Value: 1}}).
+		SetLimit(int64(limit)).
+		SetProjection(bson.M{"data": 0})
+
+	cursor, err := collection.Find(ctx, bson.M{"expire_at": bson.M{"$lte": now}}, opts)
+	if err != nil {
+		log.Printf("Error finding expired room archives: %v", err)
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var archives []models.RoomArchive
+	if err := cursor.All(ctx, &archives); err != nil {
+		return nil, err
+	}
+
+	return archives, nil
+}
+
+// DeleteRoomArchive - 보관 데이터 삭제
+func (r *ChatRepository) DeleteRoomArchive(archiveID primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	collection := r.client.Database("chat_db").Collection("room_archives")
+	_, err := collection.DeleteOne(ctx, bson.M{"_id": archiveID})
+	return err
+}
@@ -0,0 +1,44 @@
+package repo
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"solo/pkg/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 보관 데이터는 API 응답에서 빠지는 필드(수정 이력, 보내기 취소 원본)까지 원본 문서 그대로 남김
+func TestWriteExtJSONKeepsHiddenFields(t *testing.T) {
+	deletedAt := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	doc, err := bson.Marshal(models.Chat{
+		MessageId: primitive.NewObjectID(),
+		RoomID:    "room",
+		DeletedAt: &deletedAt,
+		Unsent: &models.UnsentContent{
+			Message:     "취소한 메시지",
+			EditHistory: []models.ChatEdit{{Message: "처음 메시지", EditedAt: deletedAt}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out strings.Builder
+	if err := writeExtJSON(&out, ",", bson.Raw(doc)); err != nil {
+		t.Fatalf("writeExtJSON() error = %v", err)
+	}
+
+	got := out.String()
+	if !strings.HasPrefix(got, ",{") {
+		t.Errorf("output = %s, want prefix before the document", got)
+	}
+	for _, want := range []string{`"unsent"`, "취소한 메시지", "처음 메시지", `"$oid"`, `"$date":"2026-03-01T09:00:00Z"`} {
+		if !strings.Contains(got, want) {
+			t.Errorf("archive JSON missing %s: %s", want, got)
+		}
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/samber/lo"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -28,6 +29,36 @@ func NewChatRepository(client *mongo.Client) (*ChatRepository, error) {
 	return repo, nil
 }
 
+// EnsureMessageIndexes - 기존 messages 컬렉션에 추가된 페이지네이션/검색 인덱스 생성
+// 메시지가 많으면 생성에 오래 걸리므로 시작 시 제한 시간과 분리해 백그라운드에서 실행
+func (r *ChatRepository) EnsureMessageIndexes() error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+
+	_, err := r.client.Database("chat_db").Collection("messages").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			// 커서 기반 페이지네이션 (같은 시각의 메시지는 _id로 구분)
+			Keys: bson.D{{Key: "room_id", Value: 1}, {Key: "created_at", Value: 1}, {Key: "_id", Value: 1}},
+		},
+		{
+			// 방 내 메시지 검색 (한글은 search_tokens의 n-gram으로 검색, 언어별 형태소 분석 없이 토큰 그대로 사용)
+			Keys: bson.D{
+				{Key: "room_id", Value: 1},
+				{Key: "message", Value: "text"},
+				{Key: "search_tokens", Value: "text"},
+			},
+			Options: options.Index().SetName("message_search").SetDefaultLanguage("none"),
+		},
+	})
+	if err != nil {
+		log.Printf("Error creating pagination/search indexes for messages: %v", err)
+		return err
+	}
+
+	log.Println("✅ Message pagination/search indexes ready")
+	return nil
+}
+
 // InitDatabase 데이터베이스 초기화
 func (r *ChatRepository) InitDatabase() error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -47,6 +78,10 @@ func (r *ChatRepository) InitDatabase() error {
 		"balance_games",
 		"match_histories",
 		"room_counter",
+		"message_reactions",
+		"chat_media",
+		"cleanup_jobs",
+		"room_archives",
 	}
 
 	for _, collName := range collections {
@@ -76,6 +111,48 @@ func (r *ChatRepository) InitDatabase() error {
 		return err
 	}
 
+	// message_readers 컬렉션 (메시지별 읽음 여부 조회, 채팅방 목록의 안 읽은 메시지 수 집계)
+	_, err = db.Collection("message_readers").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "message_id", Value: 1}, {Key: "user_id", Value: 1}},
+	})
+	if err != nil {
+		log.Printf("Error creating index for message_readers: %v", err)
+		return err
+	}
+
+	// cleanup_jobs 컬렉션 (방당 하나의 정리 작업 + 실행할 작업 조회)
+	_, err = db.Collection("cleanup_jobs").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "room_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "status", Value: 1}, {Key: "run_at", Value: 1}},
+		},
+	})
+	if err != nil {
+		log.Printf("Error creating indexes for cleanup_jobs: %v", err)
+		return err
+	}
+
+	// room_archives 컬렉션 (방 ID/Seq로 조회, 보관 기간 만료 조회)
+	_, err = db.Collection("room_archives").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "room_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "room_seq", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "expire_at", Value: 1}},
+		},
+	})
+	if err != nil {
+		log.Printf("Error creating indexes for room_archives: %v", err)
+		return err
+	}
+
 	// balance_form_votes 컬렉션 (중복 투표 방지를 위한 복합 인덱스)
 	votesCollection := db.Collection("balance_form_votes")
 	_, err = votesCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
@@ -90,6 +167,35 @@ func (r *ChatRepository) InitDatabase() error {
 		return err
 	}
 
+	// message_reactions 컬렉션 (같은 리액션 중복 방지 + 메시지별 조회)
+	reactionsCollection := db.Collection("message_reactions")
+	_, err = reactionsCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "message_id", Value: 1},
+				{Key: "user_id", Value: 1},
+				{Key: "reaction", Value: 1},
+			},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "room_id", Value: 1}},
+		},
+	})
+	if err != nil {
+		log.Printf("Error creating indexes for message_reactions: %v", err)
+		return err
+	}
+
+	// chat_media 컬렉션 (방 정리 시 조회)
+	_, err = db.Collection("chat_media").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "room_id", Value: 1}},
+	})
+	if err != nil {
+		log.Printf("Error creating indexes for chat_media: %v", err)
+		return err
+	}
+
 	// 밸런스 게임 초기 데이터 생성
 	balanceGames := []models.BalanceGame{
 		{
@@ -151,6 +257,8 @@ func (r *ChatRepository) InitDatabase() error {
 func (r *ChatRepository) InsertChatMessage(entry models.Chat) (primitive.ObjectID, error) {
 	collection := r.client.Database("chat_db").Collection("messages")
 
+	entry.SearchTokens = searchTokens(entry.Message)
+
 	result, err := collection.InsertOne(context.TODO(), entry)
 	if err != nil {
 		log.Println("Error inserting chat message:", err)
@@ -166,6 +274,17 @@ func (r *ChatRepository) InsertChatMessage(entry models.Chat) (primitive.ObjectI
 	return messageID, nil
 }
 
+// visibleMessageFilter: 방 메시지 중 사용자에게 보이는 메시지만 조회 (다른 사람의 귓속말 제외)
+func visibleMessageFilter(roomID string, userID int) bson.M {
+	return bson.M{
+		"room_id": roomID,
+		"$or": bson.A{
+			bson.M{"visible_to": bson.M{"$exists": false}},
+			bson.M{"visible_to": userID},
+		},
+	}
+}
+
 // 채팅방 메시지 목록 조회
 func (r *ChatRepository) GetChatMessagesByRoomID(roomID string) ([]models.Chat, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
@@ -191,14 +310,15 @@ func (r *ChatRepository) GetChatMessagesByRoomID(roomID string) ([]models.Chat,
 }
 
 // 특정 채팅방의 메시지 목록을 페이징 처리하여 조회
-func (r *ChatRepository) GetByRoomIDWithPagination(roomID string, pageNumber int, pageSize int) ([]*models.Chat, int64, error) {
+func (r *ChatRepository) GetByRoomIDWithPagination(roomID string, userID int, pageNumber int, pageSize int) ([]*models.Chat, int64, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
 	defer cancel()
 
 	collection := r.client.Database("chat_db").Collection("messages")
+	filter := visibleMessageFilter(roomID, userID)
 
 	// 총 메시지 수 계산
-	totalCount, err := collection.CountDocuments(ctx, bson.M{"room_id": roomID})
+	totalCount, err := collection.CountDocuments(ctx, filter)
 	if err != nil {
 		log.Printf("Error counting messages in room %s: %v", roomID, err)
 		return nil, 0, err
@@ -210,7 +330,7 @@ func (r *ChatRepository) GetByRoomIDWithPagination(roomID string, pageNumber int
 	opts.SetSkip(int64((pageNumber - 1) * pageSize))     // 페이지에 맞는 메시지 건너뛰기
 	opts.SetLimit(int64(pageSize))                       // 페이지당 메시지 수 제한
 
-	cursor, err := collection.Find(ctx, bson.M{"room_id": roomID}, opts)
+	cursor, err := collection.Find(ctx, filter, opts)
 	if err != nil {
 		log.Println("Error finding chat messages:", err)
 		return nil, 0, err
@@ -230,6 +350,189 @@ func (r *ChatRepository) GetByRoomIDWithPagination(roomID string, pageNumber int
 	return messages, totalCount, nil
 }
 
+// 커서 방향
+const (
+	CursorBefore = "before" // 커서보다 오래된 메시지
+	CursorAfter  = "after"  // 커서보다 새로운 메시지
+)
+
+var ErrCursorNotFound = errors.New("cursor message not found")
+
+// findCursorMessage: 커서로 사용할 메시지 조회 (다른 방의 메시지는 커서로 사용 불가)
+func (r *ChatRepository) findCursorMessage(ctx context.Context, roomID string, cursorID primitive.ObjectID) (*models.Chat, error) {
+	collection := r.client.Database("chat_db").Collection("messages")
+
+	var item models.Chat
+	err := collection.FindOne(ctx, bson.M{"_id": cursorID, "room_id": roomID}).Decode(&item)
+	if err == mongo.ErrNoDocuments {
+		return nil, ErrCursorNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// applyCursor: (created_at, _id) 순서 기준으로 커서 이전/이후 메시지만 조회하도록 필터 추가
+func applyCursor(filter bson.M, cursor *models.Chat, direction string) {
+	op := lo.Ternary(direction == CursorBefore, "$lt", "$gt")
+	filter["$and"] = bson.A{
+		bson.M{"$or": bson.A{
+			bson.M{"created_at": bson.M{op: cursor.CreatedAt}},
+			bson.M{"created_at": cursor.CreatedAt, "_id": bson.M{op: cursor.MessageId}},
+		}},
+	}
+}
+
+// 커서(메시지 ID) 기준으로 이전/이후 메시지를 최신순으로 최대 limit개 조회
+// hasMore는 조회 방향으로 메시지가 더 남아있는지 여부
+func (r *ChatRepository) GetByRoomIDWithCursor(roomID string, userID int, cursorID primitive.ObjectID, direction string, limit int) ([]*models.Chat, bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	cursor, err := r.findCursorMessage(ctx, roomID, cursorID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return r.findAroundCursor(ctx, roomID, userID, cursor, direction, limit)
+}
+
+// 이미 조회한 메시지 기준으로 이전/이후 메시지 조회 (커서 메시지를 다시 찾지 않음)
+func (r *ChatRepository) GetMessagesAround(message *models.Chat, userID int, direction string, limit int) ([]*models.Chat, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	messages, _, err := r.findAroundCursor(ctx, message.RoomID, userID, message, direction, limit)
+	return messages, err
+}
+
+func (r *ChatRepository) findAroundCursor(ctx context.Context, roomID string, userID int, cursor *models.Chat, direction string, limit int) ([]*models.Chat, bool, error) {
+	collection := r.client.Database("chat_db").Collection("messages")
+	filter := visibleMessageFilter(roomID, userID)
+	applyCursor(filter, cursor, direction)
+
+	// 커서에 가까운 메시지부터 limit+1개 조회해 더 남아있는지 확인
+	order := lo.Ternary(direction == CursorBefore, -1, 1)
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: order}, {Key: "_id", Value: order}}).
+		SetLimit(int64(limit + 1))
+
+	findCursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		log.Printf("Error finding chat messages %s %s in room %s: %v", direction, cursor.MessageId.Hex(), roomID, err)
+		return nil, false, err
+	}
+	defer findCursor.Close(ctx)
+
+	var messages []*models.Chat
+	if err := findCursor.All(ctx, &messages); err != nil {
+		log.Printf("Error decoding chat messages in room %s: %v", roomID, err)
+		return nil, false, err
+	}
+
+	messages, hasMore := cursorPage(messages, direction, limit)
+	return messages, hasMore, nil
+}
+
+// cursorPage: 커서에 가까운 순으로 limit+1개 조회한 결과를 limit개로 자르고 최신순으로 정렬
+func cursorPage(messages []*models.Chat, direction string, limit int) ([]*models.Chat, bool) {
+	hasMore := len(messages) > limit
+	if hasMore {
+		messages = messages[:limit]
+	}
+	if direction == CursorAfter {
+		// 페이지 모드와 같이 최신순으로 반환
+		messages = lo.Reverse(messages)
+	}
+
+	return messages, hasMore
+}
+
+// 사용자에게 보이는 방 메시지를 오래된 순으로 하나씩 전달 (긴 대화도 한 번에 메모리에 올리지 않음)
+func (r *ChatRepository) StreamVisibleMessages(roomID string, userID int, fn func(*models.Chat) error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	collection := r.client.Database("chat_db").Collection("messages")
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}).
+		SetBatchSize(200).
+		SetProjection(bson.M{"edit_history": 0, "search_tokens": 0})
+
+	cursor, err := collection.Find(ctx, visibleMessageFilter(roomID, userID), opts)
+	if err != nil {
+		log.Printf("Error streaming messages in room %s: %v", roomID, err)
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var item models.Chat
+		if err := cursor.Decode(&item); err != nil {
+			log.Printf("Error decoding streamed message in room %s: %v", roomID, err)
+			return err
+		}
+		if err := fn(&item); err != nil {
+			return err
+		}
+	}
+
+	return cursor.Err()
+}
+
+// 마지막으로 본 메시지 이후의 메시지를 오래된 순으로 최대 limit개 조회 (더 남아있으면 hasMore)
+// lastSeenID가 없거나 찾을 수 없으면 최근 limit개를 반환
+func (r *ChatRepository) GetMessagesAfter(roomID string, userID int, lastSeenID primitive.ObjectID, limit int) ([]*models.Chat, bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	collection := r.client.Database("chat_db").Collection("messages")
+	filter := visibleMessageFilter(roomID, userID)
+
+	var lastSeen *models.Chat
+	if !lastSeenID.IsZero() {
+		item, err := r.findCursorMessage(ctx, roomID, lastSeenID)
+		if err != nil && !errors.Is(err, ErrCursorNotFound) {
+			log.Printf("Error finding last seen message %s in room %s: %v", lastSeenID.Hex(), roomID, err)
+			return nil, false, err
+		}
+		lastSeen = item
+	}
+
+	opts := options.Find().SetLimit(int64(limit + 1))
+	if lastSeen != nil {
+		applyCursor(filter, lastSeen, CursorAfter)
+		opts.SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}})
+	} else {
+		opts.SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}})
+	}
+
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		log.Printf("Error finding messages after %s in room %s: %v", lastSeenID.Hex(), roomID, err)
+		return nil, false, err
+	}
+	defer cursor.Close(ctx)
+
+	var messages []*models.Chat
+	if err := cursor.All(ctx, &messages); err != nil {
+		log.Printf("Error decoding messages in room %s: %v", roomID, err)
+		return nil, false, err
+	}
+
+	hasMore := len(messages) > limit
+	if hasMore {
+		messages = messages[:limit]
+	}
+	if lastSeen == nil {
+		// 최신순으로 가져왔으므로 오래된 순으로 뒤집기
+		messages = lo.Reverse(messages)
+	}
+
+	return messages, hasMore, nil
+}
+
 // 특정 방에서 before 시간 이전에 존재하는 읽지 않은 메시지 리스트 조회
 func (r *ChatRepository) GetUnreadMessagesBefore(roomID string, before time.Time, userID int) ([]models.Chat, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
@@ -237,8 +540,11 @@ func (r *ChatRepository) GetUnreadMessagesBefore(roomID string, before time.Time
 
 	collection := r.client.Database("chat_db").Collection("messages")
 
+	filter := visibleMessageFilter(roomID, userID)
+	filter["created_at"] = bson.M{"$lt": before}
+
 	pipeline := mongo.Pipeline{
-		bson.D{{Key: "$match", Value: bson.M{"room_id": roomID, "created_at": bson.M{"$lt": before}}}},
+		bson.D{{Key: "$match", Value: filter}},
 		bson.D{{Key: "$lookup", Value: bson.M{
 			"from":         "message_readers",
 			"localField":   "_id",
@@ -295,14 +601,14 @@ func (r *ChatRepository) UpdateUnreadCounts(messageIDs []primitive.ObjectID) err
 }
 
 // 특정 방의 최신 메시지 조회
-func (r *ChatRepository) GetLastMessageByRoomID(roomID string) (*models.Chat, error) {
+func (r *ChatRepository) GetLastMessageByRoomID(roomID string, userID int) (*models.Chat, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
 	defer cancel()
 
 	collection := r.client.Database("chat_db").Collection("messages")
 
 	var lastMessage models.Chat
-	err := collection.FindOne(ctx, bson.M{"room_id": roomID}, options.FindOne().SetSort(bson.D{{Key: "created_at", Value: -1}})).Decode(&lastMessage)
+	err := collection.FindOne(ctx, visibleMessageFilter(roomID, userID), options.FindOne().SetSort(bson.D{{Key: "created_at", Value: -1}})).Decode(&lastMessage)
 	if err == mongo.ErrNoDocuments {
 		return &models.Chat{
 			Message:   "",
@@ -357,7 +663,7 @@ func (r *ChatRepository) GetUnreadCountByUserAndRoom(userID int, roomID string)
 	messagesCollection := r.client.Database("chat_db").Collection("messages")
 
 	pipeline := mongo.Pipeline{
-		bson.D{{Key: "$match", Value: bson.M{"room_id": roomID}}},
+		bson.D{{Key: "$match", Value: visibleMessageFilter(roomID, userID)}},
 		bson.D{{Key: "$lookup", Value: bson.M{
 			"from":         "message_readers",
 			"localField":   "_id",
@@ -551,6 +857,90 @@ func (r *ChatRepository) GetRoomsByUserID(userID int) ([]models.ChatRoom, error)
 	return rooms, nil
 }
 
+// 사용자의 모든 채팅방과 방별 마지막 메시지, 안 읽은 메시지 수, 사용자 캐릭터 정보를 한 번에 조회
+func (r *ChatRepository) GetRoomSummariesByUserID(userID int) ([]models.ChatRoomSummary, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	// 방 메시지 중 사용자에게 보이는 메시지 (다른 사람의 귓속말 제외)
+	visibleInRoom := bson.M{
+		"$expr": bson.M{"$eq": bson.A{"$room_id", "$$roomId"}},
+		"$or": bson.A{
+			bson.M{"visible_to": bson.M{"$exists": false}},
+			bson.M{"visible_to": userID},
+		},
+	}
+
+	pipeline := mongo.Pipeline{
+		bson.D{{Key: "$match", Value: bson.M{"user_ids": userID}}},
+		bson.D{{Key: "$lookup", Value: bson.M{
+			"from": "messages",
+			"let":  bson.M{"roomId": "$id"},
+			"pipeline": bson.A{
+				bson.M{"$match": visibleInRoom},
+				bson.M{"$sort": bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}},
+				bson.M{"$limit": 1},
+				bson.M{"$project": bson.M{"edit_history": 0, "search_tokens": 0}},
+			},
+			"as": "last_messages",
+		}}},
+		bson.D{{Key: "$lookup", Value: bson.M{
+			"from": "messages",
+			"let":  bson.M{"roomId": "$id"},
+			"pipeline": bson.A{
+				bson.M{"$match": visibleInRoom},
+				bson.M{"$lookup": bson.M{
+					"from": "message_readers",
+					"let":  bson.M{"messageId": "$_id"},
+					"pipeline": bson.A{
+						bson.M{"$match": bson.M{
+							"$expr":   bson.M{"$eq": bson.A{"$message_id", "$$messageId"}},
+							"user_id": userID,
+						}},
+						bson.M{"$limit": 1},
+					},
+					"as": "readers",
+				}},
+				bson.M{"$match": bson.M{"readers": bson.M{"$size": 0}}},
+				bson.M{"$count": "count"},
+			},
+			"as": "unread",
+		}}},
+		bson.D{{Key: "$addFields", Value: bson.M{
+			"last_message": bson.M{"$arrayElemAt": bson.A{"$last_messages", 0}},
+			"unread_count": bson.M{"$ifNull": bson.A{bson.M{"$arrayElemAt": bson.A{"$unread.count", 0}}, 0}},
+			"gamer": bson.M{"$arrayElemAt": bson.A{
+				bson.M{"$filter": bson.M{
+					"input": bson.M{"$ifNull": bson.A{"$gamers", bson.A{}}},
+					"cond":  bson.M{"$eq": bson.A{"$$this.user_id", userID}},
+				}},
+				0,
+			}},
+		}}},
+		bson.D{{Key: "$project", Value: bson.M{"last_messages": 0, "unread": 0}}},
+	}
+
+	cursor, err := r.client.Database("chat_db").Collection("rooms").Aggregate(ctx, pipeline)
+	if err != nil {
+		log.Printf("Error aggregating room summaries for user %d: %v", userID, err)
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var summaries []models.ChatRoomSummary
+	for cursor.Next(ctx) {
+		var summary models.ChatRoomSummary
+		if err := cursor.Decode(&summary); err != nil {
+			// 한 방의 데이터가 잘못되어도 나머지 목록은 반환
+			log.Printf("Error decoding room summary for user %d: %v", userID, err)
+			continue
+		}
+		summaries = append(summaries, summary)
+	}
+
+	return summaries, cursor.Err()
+}
+
 // 특정 채팅방 내에서 사용자의 게임 정보 조회
 func (r *ChatRepository) GetUserGameInfoInRoom(userID int, roomID string) (*models.GamerInfo, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -606,6 +996,39 @@ func (r *ChatRepository) UpdateChatRoomStatus(roomID string, status int) error {
 	return nil
 }
 
+// 방 대화/최종 선택 종료 시각 연장
+func (r *ChatRepository) ExtendRoomTime(roomID string, finishChatAt, finishFinalChoiceAt time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	collection := r.client.Database("chat_db").Collection("rooms")
+
+	filter := bson.M{"id": roomID}
+	update := bson.M{
+		"$set": bson.M{
+			"finish_chat_at":         finishChatAt,
+			"finish_final_choice_at": finishFinalChoiceAt,
+			"modified_at":            time.Now(),
+		},
+		"$inc": bson.M{
+			"extend_count": 1,
+		},
+	}
+
+	result, err := collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		log.Printf("Error extending time for room %s: %v", roomID, err)
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return errors.New("room not found")
+	}
+
+	log.Printf("Successfully extended room %s until %s", roomID, finishChatAt.Format(time.RFC3339))
+	return nil
+}
+
 func (r *ChatRepository) GetNextSequence(sequenceName string) (int64, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -665,6 +1088,58 @@ func (r *ChatRepository) GetBalanceFormByID(formID primitive.ObjectID) (*models.
 	return &form, nil
 }
 
+// 밸런스 게임 폼 종료 처리, 이미 종료된 폼이면 false
+func (r *ChatRepository) FinishBalanceForm(formID primitive.ObjectID) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	collection := r.client.Database("chat_db").Collection("balance_forms")
+	result, err := collection.UpdateOne(ctx,
+		bson.M{"_id": formID, "finished_at": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"finished_at": time.Now()}},
+	)
+	if err != nil {
+		log.Printf("Error finishing balance form %s: %v", formID.Hex(), err)
+		return false, err
+	}
+
+	return result.ModifiedCount == 1, nil
+}
+
+// 밸런스 게임 결과 전송 선점 (이미 선점됐으면 false)
+func (r *ChatRepository) ClaimBalanceFormResult(formID primitive.ObjectID) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	collection := r.client.Database("chat_db").Collection("balance_forms")
+	result, err := collection.UpdateOne(ctx,
+		bson.M{"_id": formID, "result_at": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"result_at": time.Now()}},
+	)
+	if err != nil {
+		log.Printf("Error claiming balance form result %s: %v", formID.Hex(), err)
+		return false, err
+	}
+
+	return result.ModifiedCount == 1, nil
+}
+
+// 밸런스 게임 결과 전송 선점 해제 (전송 실패 시 재시도할 수 있도록)
+func (r *ChatRepository) ReleaseBalanceFormResult(formID primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	collection := r.client.Database("chat_db").Collection("balance_forms")
+	_, err := collection.UpdateOne(ctx,
+		bson.M{"_id": formID},
+		bson.M{"$unset": bson.M{"result_at": ""}},
+	)
+	if err != nil {
+		log.Printf("Error releasing balance form result %s: %v", formID.Hex(), err)
+	}
+	return err
+}
+
 // 밸런스 게임 폼 삽입
 func (r *ChatRepository) InsertBalanceForm(form *models.BalanceGameForm) (primitive.ObjectID, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -776,6 +1251,28 @@ func (r *ChatRepository) GetUserVote(formID primitive.ObjectID, userID int) (*mo
 	return &vote, nil
 }
 
+// 폼의 전체 투표 기록 조회
+func (r *ChatRepository) GetBalanceFormVotes(formID primitive.ObjectID) ([]models.BalanceFormVote, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	collection := r.client.Database("chat_db").Collection("balance_form_votes")
+
+	cursor, err := collection.Find(ctx, bson.M{"form_id": formID})
+	if err != nil {
+		log.Printf("Error finding votes for form %s: %v", formID.Hex(), err)
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var votes []models.BalanceFormVote
+	if err := cursor.All(ctx, &votes); err != nil {
+		return nil, err
+	}
+
+	return votes, nil
+}
+
 func (r *ChatRepository) GetRoomIdByBalanceFormID(formID primitive.ObjectID) (string, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -814,29 +1311,32 @@ func (r *ChatRepository) InsertBalanceFormVote(vote *models.BalanceFormVote) err
 			return errors.New("user already voted")
 		}
 
-		// 2. 투표 기록 저장
-		voteRecordsCollection := r.client.Database("chat_db").Collection("balance_form_votes")
-		vote.CreatedAt = time.Now() // 생성 시간 설정
-		_, err = voteRecordsCollection.InsertOne(sessCtx, vote)
-		if err != nil {
-			log.Printf("Error inserting vote record: %v", err)
-			return err
-		}
-
-		// 3. 투표 수 증가
+		// 2. 투표 수 증가 (종료된 게임은 집계를 바꾸지 않음)
 		votesCollection := r.client.Database("chat_db").Collection("balance_forms")
 		updateField := "votes.blue_cnt"
 		if vote.Choiced == commontype.BalanceFormVoteRed {
 			updateField = "votes.red_cnt"
 		}
 
-		_, err = votesCollection.UpdateOne(sessCtx,
-			bson.M{"_id": vote.FormID},
+		result, err := votesCollection.UpdateOne(sessCtx,
+			bson.M{"_id": vote.FormID, "finished_at": bson.M{"$exists": false}},
 			bson.M{"$inc": bson.M{updateField: 1}})
 		if err != nil {
 			log.Printf("Error updating vote count: %v", err)
 			return err
 		}
+		if result.MatchedCount == 0 {
+			return errors.New("balance form finished")
+		}
+
+		// 3. 투표 기록 저장
+		voteRecordsCollection := r.client.Database("chat_db").Collection("balance_form_votes")
+		vote.CreatedAt = time.Now() // 생성 시간 설정
+		_, err = voteRecordsCollection.InsertOne(sessCtx, vote)
+		if err != nil {
+			log.Printf("Error inserting vote record: %v", err)
+			return err
+		}
 
 		return nil
 	}
@@ -866,20 +1366,23 @@ func (r *ChatRepository) CancelVote(formID primitive.ObjectID, userID int) error
 			return errors.New("no vote found to cancel")
 		}
 
-		// 2. 투표 수 감소
+		// 2. 투표 수 감소 (종료된 게임은 집계를 바꾸지 않음)
 		votesCollection := r.client.Database("chat_db").Collection("balance_forms")
 		updateField := "votes.blue_cnt"
 		if existingVote.Choiced == commontype.BalanceFormVoteRed {
 			updateField = "votes.red_cnt"
 		}
 
-		_, err = votesCollection.UpdateOne(sessCtx,
-			bson.M{"_id": formID},
+		result, err := votesCollection.UpdateOne(sessCtx,
+			bson.M{"_id": formID, "finished_at": bson.M{"$exists": false}},
 			bson.M{"$inc": bson.M{updateField: -1}})
 		if err != nil {
 			log.Printf("Error decreasing vote count: %v", err)
 			return err
 		}
+		if result.MatchedCount == 0 {
+			return errors.New("balance form finished")
+		}
 
 		// 3. 투표 기록 삭제
 		voteRecordsCollection := r.client.Database("chat_db").Collection("balance_form_votes")
@@ -939,6 +1442,32 @@ func (r *ChatRepository) UpdateMatchHistoryBalanceResult(roomSeq int, balanceRes
 	return nil
 }
 
+func (r *ChatRepository) UpdateMatchHistoryFirstImpression(roomSeq int, result models.FirstImpressionResult) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	collection := r.client.Database("chat_db").Collection("match_histories")
+
+	filter := bson.M{"room_seq": roomSeq}
+	update := bson.M{
+		"$push": bson.M{
+			"first_impressions": result,
+		},
+	}
+
+	updateResult, err := collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		log.Printf("Error updating first impression result for room seq %d: %v", roomSeq, err)
+		return err
+	}
+
+	if updateResult.MatchedCount == 0 {
+		return errors.New("match history not found")
+	}
+
+	return nil
+}
+
 func (r *ChatRepository) UpdateMatchHistoryFinalMatch(roomSeq int, finalMatch []string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -1035,7 +1564,7 @@ func (r *ChatRepository) DeleteBalanceFormComments(formID primitive.ObjectID) er
 	defer cancel()
 
 	collection := r.client.Database("chat_db").Collection("balance_form_comments")
-	_, err := collection.DeleteMany(ctx, bson.M{"form_id": formID})
+	_, err := collection.DeleteMany(ctx, bson.M{"balance_form_id": formID})
 	return err
 }
 
@@ -0,0 +1,88 @@
+package repo
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"solo/pkg/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestVisibleMessageFilter(t *testing.T) {
+	filter := visibleMessageFilter("room-1", 7)
+
+	if filter["room_id"] != "room-1" {
+		t.Errorf("room_id = %v, want room-1", filter["room_id"])
+	}
+
+	or, ok := filter["$or"].(bson.A)
+	if !ok || len(or) != 2 {
+		t.Fatalf("$or = %#v, want public and own whisper conditions", filter["$or"])
+	}
+
+	// 일반 메시지(visible_to 없음)와 본인이 포함된 귓속말만 조회
+	public, _ := or[0].(bson.M)
+	if exists, _ := public["visible_to"].(bson.M); exists["$exists"] != false {
+		t.Errorf("first condition = %#v, want visible_to not set", public)
+	}
+	own, _ := or[1].(bson.M)
+	if own["visible_to"] != 7 {
+		t.Errorf("second condition = %#v, want visible_to containing user 7", own)
+	}
+}
+
+func TestApplyCursor(t *testing.T) {
+	cursor := &models.Chat{MessageId: primitive.NewObjectID(), CreatedAt: time.Now()}
+
+	for direction, op := range map[string]string{CursorBefore: "$lt", CursorAfter: "$gt"} {
+		filter := visibleMessageFilter("room-1", 7)
+		applyCursor(filter, cursor, direction)
+
+		// 기존 방/귓속말 조건은 유지
+		if filter["room_id"] != "room-1" || filter["$or"] == nil {
+			t.Errorf("%s: visibility conditions lost: %v", direction, filter)
+		}
+
+		and := filter["$and"].(bson.A)
+		or := and[0].(bson.M)["$or"].(bson.A)
+
+		// 같은 시각에 보낸 메시지는 _id로 순서 결정
+		byTime := or[0].(bson.M)["created_at"].(bson.M)
+		tie := or[1].(bson.M)
+		if byTime[op] != cursor.CreatedAt {
+			t.Errorf("%s: created_at = %v, want %s cursor time", direction, byTime, op)
+		}
+		if tie["created_at"] != cursor.CreatedAt || tie["_id"].(bson.M)[op] != cursor.MessageId {
+			t.Errorf("%s: tie-break = %v, want same time and _id %s cursor", direction, tie, op)
+		}
+	}
+}
+
+func TestCursorPage(t *testing.T) {
+	// 조회 결과는 커서에 가까운 순서
+	newer := func(n int) []*models.Chat {
+		messages := make([]*models.Chat, n)
+		for i := range messages {
+			messages[i] = &models.Chat{Message: fmt.Sprintf("after-%d", i+1)}
+		}
+		return messages
+	}
+
+	page, hasMore := cursorPage(newer(4), CursorAfter, 3)
+	if !hasMore || len(page) != 3 {
+		t.Fatalf("after page: len=%d hasMore=%t, want 3 and more", len(page), hasMore)
+	}
+	// 이후 방향도 최신순으로 반환
+	if page[0].Message != "after-3" || page[2].Message != "after-1" {
+		t.Errorf("after page order = %s..%s, want after-3..after-1", page[0].Message, page[2].Message)
+	}
+
+	older := []*models.Chat{{Message: "before-1"}, {Message: "before-2"}}
+	page, hasMore = cursorPage(older, CursorBefore, 3)
+	if hasMore || len(page) != 2 || page[0].Message != "before-1" {
+		t.Errorf("before page = %d messages starting %s, hasMore=%t, want both in order and no more", len(page), page[0].Message, hasMore)
+	}
+}
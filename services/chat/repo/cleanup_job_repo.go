@@ -0,0 +1,145 @@
+package repo
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"solo/pkg/models"
+	"solo/pkg/types/commontype"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ScheduleCleanupJob - 방 정리 작업 예약, 이미 예약된 작업이 있으면 그대로 둠
+func (r *ChatRepository) ScheduleCleanupJob(roomID string, runAt time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	collection := r.client.Database("chat_db").Collection("cleanup_jobs")
+
+	now := time.Now()
+	update := bson.M{"$setOnInsert": bson.M{
+		"room_id":    roomID,
+		"run_at":     runAt,
+		"status":     commontype.CleanupJobStatusPending,
+		"steps":      bson.A{},
+		"attempts":   0,
+		"created_at": now,
+		"updated_at": now,
+	}}
+
+	_, err := collection.UpdateOne(ctx, bson.M{"room_id": roomID}, update, options.Update().SetUpsert(true))
+	if err != nil {
+		log.Printf("Error scheduling cleanup job for room %s: %v", roomID, err)
+		return err
+	}
+
+	return nil
+}
+
+// ClaimDueCleanupJob - 실행 시각이 지난 작업 하나를 점유, 없으면 nil
+// 점유 시간이 지난 실행 중 작업(워커가 중간에 종료된 경우)도 다시 가져옴
+func (r *ChatRepository) ClaimDueCleanupJob(now time.Time, lease time.Duration) (*models.CleanupJob, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	collection := r.client.Database("chat_db").Collection("cleanup_jobs")
+
+	filter := bson.M{
+		"run_at": bson.M{"$lte": now},
+		"$or": bson.A{
+			bson.M{"status": commontype.CleanupJobStatusPending},
+			bson.M{"status": commontype.CleanupJobStatusRunning, "locked_until": bson.M{"$lt": now}},
+		},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"status":       commontype.CleanupJobStatusRunning,
+			"locked_until": now.Add(lease),
+			"updated_at":   now,
+		},
+		"$inc": bson.M{"attempts": 1},
+	}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "run_at", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var job models.CleanupJob
+	err := collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&job)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		log.Printf("Error claiming cleanup job: %v", err)
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+// RenewCleanupJobLease - 실행 중인 작업의 점유 시간 연장 (오래 걸리는 단계 중 다른 워커가 가져가지 않도록)
+func (r *ChatRepository) RenewCleanupJobLease(jobID primitive.ObjectID, lease time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	collection := r.client.Database("chat_db").Collection("cleanup_jobs")
+	_, err := collection.UpdateOne(ctx,
+		bson.M{"_id": jobID, "status": commontype.CleanupJobStatusRunning},
+		bson.M{"$set": bson.M{"locked_until": now.Add(lease), "updated_at": now}},
+	)
+	return err
+}
+
+// MarkCleanupStepDone - 정리 단계 완료 기록
+func (r *ChatRepository) MarkCleanupStepDone(jobID primitive.ObjectID, step string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	collection := r.client.Database("chat_db").Collection("cleanup_jobs")
+	_, err := collection.UpdateByID(ctx, jobID, bson.M{
+		"$addToSet": bson.M{"steps": step},
+		"$set":      bson.M{"updated_at": time.Now()},
+	})
+	return err
+}
+
+// FinishCleanupJob - 작업 종료 (done, cancelled)
+func (r *ChatRepository) FinishCleanupJob(jobID primitive.ObjectID, status string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	collection := r.client.Database("chat_db").Collection("cleanup_jobs")
+	_, err := collection.UpdateByID(ctx, jobID, bson.M{
+		"$set":   bson.M{"status": status, "updated_at": time.Now()},
+		"$unset": bson.M{"locked_until": "", "last_error": ""},
+	})
+	return err
+}
+
+// FailCleanupJob - 실패 기록 후 retryAt에 재시도, 최대 시도 횟수를 넘으면 failed로 남김
+func (r *ChatRepository) FailCleanupJob(job *models.CleanupJob, cause error, retryAt time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	status := commontype.CleanupJobStatusPending
+	if job.Attempts >= commontype.CleanupJobMaxAttempts {
+		status = commontype.CleanupJobStatusFailed
+	}
+
+	collection := r.client.Database("chat_db").Collection("cleanup_jobs")
+	_, err := collection.UpdateByID(ctx, job.ID, bson.M{
+		"$set": bson.M{
+			"status":     status,
+			"run_at":     retryAt,
+			"last_error": cause.Error(),
+			"updated_at": time.Now(),
+		},
+		"$unset": bson.M{"locked_until": ""},
+	})
+	return err
+}
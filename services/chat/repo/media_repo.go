@@ -0,0 +1,133 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"solo/pkg/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// 업로드된 채팅 미디어 저장
+func (r *ChatRepository) InsertChatMedia(chatMedia *models.ChatMedia) (primitive.ObjectID, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	collection := r.client.Database("chat_db").Collection("chat_media")
+
+	chatMedia.CreatedAt = time.Now()
+	result, err := collection.InsertOne(ctx, chatMedia)
+	if err != nil {
+		log.Printf("Error inserting chat media: %v", err)
+		return primitive.NilObjectID, err
+	}
+
+	insertedID, ok := result.InsertedID.(primitive.ObjectID)
+	if !ok {
+		return primitive.NilObjectID, errors.New("failed to convert InsertedID to ObjectID")
+	}
+
+	return insertedID, nil
+}
+
+// 채팅 미디어 단건 조회
+func (r *ChatRepository) GetChatMediaByID(mediaID primitive.ObjectID) (*models.ChatMedia, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	collection := r.client.Database("chat_db").Collection("chat_media")
+
+	var chatMedia models.ChatMedia
+	err := collection.FindOne(ctx, bson.M{"_id": mediaID}).Decode(&chatMedia)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		log.Printf("Error finding chat media %s: %v", mediaID.Hex(), err)
+		return nil, err
+	}
+
+	return &chatMedia, nil
+}
+
+// 미디어를 메시지 하나에 연결 (이미 다른 메시지에서 사용 중이면 false)
+func (r *ChatRepository) BindChatMediaToMessage(mediaID, messageID primitive.ObjectID) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	collection := r.client.Database("chat_db").Collection("chat_media")
+	result, err := collection.UpdateOne(ctx,
+		bson.M{"_id": mediaID, "message_id": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"message_id": messageID}},
+	)
+	if err != nil {
+		log.Printf("Error binding chat media %s to message %s: %v", mediaID.Hex(), messageID.Hex(), err)
+		return false, err
+	}
+
+	return result.ModifiedCount == 1, nil
+}
+
+// 메시지 연결 해제 (메시지 발행에 실패한 경우)
+func (r *ChatRepository) UnbindChatMedia(mediaID, messageID primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	collection := r.client.Database("chat_db").Collection("chat_media")
+	_, err := collection.UpdateOne(ctx,
+		bson.M{"_id": mediaID, "message_id": messageID},
+		bson.M{"$unset": bson.M{"message_id": ""}},
+	)
+	if err != nil {
+		log.Printf("Error unbinding chat media %s: %v", mediaID.Hex(), err)
+	}
+	return err
+}
+
+// 방의 채팅 미디어 목록 조회
+func (r *ChatRepository) GetChatMediaByRoomID(roomID string) ([]models.ChatMedia, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	collection := r.client.Database("chat_db").Collection("chat_media")
+
+	cursor, err := collection.Find(ctx, bson.M{"room_id": roomID})
+	if err != nil {
+		log.Printf("Error finding chat media of room %s: %v", roomID, err)
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var mediaList []models.ChatMedia
+	if err := cursor.All(ctx, &mediaList); err != nil {
+		log.Printf("Error decoding chat media of room %s: %v", roomID, err)
+		return nil, err
+	}
+
+	return mediaList, nil
+}
+
+// DeleteChatMediaByID deletes a single chat media record
+func (r *ChatRepository) DeleteChatMediaByID(mediaID primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	collection := r.client.Database("chat_db").Collection("chat_media")
+	_, err := collection.DeleteOne(ctx, bson.M{"_id": mediaID})
+	return err
+}
+
+// DeleteChatMediaByRoomID deletes all chat media records for a room
+func (r *ChatRepository) DeleteChatMediaByRoomID(roomID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	collection := r.client.Database("chat_db").Collection("chat_media")
+	_, err := collection.DeleteMany(ctx, bson.M{"room_id": roomID})
+	return err
+}
@@ -0,0 +1,97 @@
+package repo
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"solo/pkg/models"
+	"solo/pkg/types/commontype"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// editableMessageFilter - 발신자 본인의, 삭제되지 않은, since 이후에 보낸 메시지
+func editableMessageFilter(messageID primitive.ObjectID, senderID int, since time.Time, types []string) bson.M {
+	return bson.M{
+		"_id":        messageID,
+		"sender_id":  senderID,
+		"type":       bson.M{"$in": types},
+		"deleted_at": bson.M{"$exists": false},
+		"created_at": bson.M{"$gte": since},
+	}
+}
+
+// 메시지 수정 (이전 내용은 edit_history에 보관), 조건에 맞는 메시지가 없으면 nil
+func (r *ChatRepository) EditChatMessage(messageID primitive.ObjectID, senderID int, message string, since time.Time) (*models.Chat, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	collection := r.client.Database("chat_db").Collection("messages")
+
+	filter := editableMessageFilter(messageID, senderID, since, []string{commontype.ChatTypeChat, commontype.ChatTypeWhisper})
+
+	// 이전 메시지를 같은 업데이트 안에서 이력으로 옮기기 위해 파이프라인 업데이트 사용
+	now := time.Now()
+	update := mongo.Pipeline{
+		bson.D{{Key: "$set", Value: bson.M{
+			"edit_history": bson.M{"$concatArrays": bson.A{
+				bson.M{"$ifNull": bson.A{"$edit_history", bson.A{}}},
+				bson.A{bson.M{"message": "$message", "edited_at": now}},
+			}},
+			"message":       bson.M{"$literal": message},
+			"search_tokens": bson.M{"$literal": searchTokens(message)},
+			"edited_at":     now,
+		}}},
+	}
+
+	var chat models.Chat
+	err := collection.FindOneAndUpdate(ctx, filter, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&chat)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		log.Printf("Error editing message %s: %v", messageID.Hex(), err)
+		return nil, err
+	}
+
+	return &chat, nil
+}
+
+// 메시지 보내기 취소 (내용을 비운 툼스톤으로 남기고 원본은 unsent에 보관), 조건에 맞는 메시지가 없으면 nil
+func (r *ChatRepository) DeleteChatMessage(messageID primitive.ObjectID, senderID int, since time.Time) (*models.Chat, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	collection := r.client.Database("chat_db").Collection("messages")
+
+	filter := editableMessageFilter(messageID, senderID, since, []string{commontype.ChatTypeChat, commontype.ChatTypeWhisper, commontype.ChatTypeImage})
+	// 원본 내용과 수정 이력은 보관 데이터에 남도록 unsent로 옮긴 후 비움 (API 응답에는 노출되지 않음)
+	update := mongo.Pipeline{
+		bson.D{{Key: "$set", Value: bson.M{
+			"unsent": bson.M{
+				"message":      "$message",
+				"edit_history": "$edit_history",
+				"media":        "$media",
+			},
+			"message":    "",
+			"deleted_at": time.Now(),
+		}}},
+		bson.D{{Key: "$unset", Value: bson.A{"edit_history", "edited_at", "media", "search_tokens"}}},
+	}
+
+	var chat models.Chat
+	err := collection.FindOneAndUpdate(ctx, filter, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&chat)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		log.Printf("Error deleting message %s: %v", messageID.Hex(), err)
+		return nil, err
+	}
+
+	return &chat, nil
+}
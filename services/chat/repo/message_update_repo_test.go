@@ -0,0 +1,34 @@
+package repo
+
+import (
+	"testing"
+	"time"
+
+	"solo/pkg/types/commontype"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestEditableMessageFilter(t *testing.T) {
+	messageID := primitive.NewObjectID()
+	since := time.Now().Add(-commontype.MessageEditWindow)
+	types := []string{commontype.ChatTypeChat}
+
+	filter := editableMessageFilter(messageID, 3, since, types)
+
+	// 다른 사람의 메시지, 이미 취소된 메시지, 수정 가능 시간이 지난 메시지는 제외
+	if filter["_id"] != messageID || filter["sender_id"] != 3 {
+		t.Errorf("filter = %v, want message %s sent by user 3", filter, messageID.Hex())
+	}
+	if deleted, _ := filter["deleted_at"].(bson.M); deleted["$exists"] != false {
+		t.Errorf("deleted_at = %v, want not set", filter["deleted_at"])
+	}
+	if created, _ := filter["created_at"].(bson.M); created["$gte"] != since {
+		t.Errorf("created_at = %v, want >= %v", filter["created_at"], since)
+	}
+	in, _ := filter["type"].(bson.M)
+	if got, _ := in["$in"].([]string); len(got) != 1 || got[0] != commontype.ChatTypeChat {
+		t.Errorf("type = %v, want $in %v", filter["type"], types)
+	}
+}
@@ -0,0 +1,199 @@
+package repo
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"solo/pkg/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// 메시지 단건 조회
+func (r *ChatRepository) GetChatByID(messageID primitive.ObjectID) (*models.Chat, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	collection := r.client.Database("chat_db").Collection("messages")
+
+	var chat models.Chat
+	err := collection.FindOne(ctx, bson.M{"_id": messageID}).Decode(&chat)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		log.Printf("Error finding message %s: %v", messageID.Hex(), err)
+		return nil, err
+	}
+
+	return &chat, nil
+}
+
+// 리액션 추가 (이미 같은 리액션이 있으면 false)
+func (r *ChatRepository) AddReaction(reaction models.MessageReaction) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	collection := r.client.Database("chat_db").Collection("message_reactions")
+
+	filter := bson.M{
+		"message_id": reaction.MessageID,
+		"user_id":    reaction.UserID,
+		"reaction":   reaction.Reaction,
+	}
+	update := bson.M{
+		"$setOnInsert": bson.M{
+			"room_id":    reaction.RoomID,
+			"created_at": time.Now(),
+		},
+	}
+
+	result, err := collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if err != nil {
+		log.Printf("Error adding reaction to message %s: %v", reaction.MessageID.Hex(), err)
+		return false, err
+	}
+
+	return result.UpsertedCount > 0, nil
+}
+
+// 리액션 제거 (제거할 리액션이 없으면 false)
+func (r *ChatRepository) RemoveReaction(messageID primitive.ObjectID, userID int, reaction string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	collection := r.client.Database("chat_db").Collection("message_reactions")
+
+	result, err := collection.DeleteOne(ctx, bson.M{
+		"message_id": messageID,
+		"user_id":    userID,
+		"reaction":   reaction,
+	})
+	if err != nil {
+		log.Printf("Error removing reaction from message %s: %v", messageID.Hex(), err)
+		return false, err
+	}
+
+	return result.DeletedCount > 0, nil
+}
+
+// 메시지의 특정 리액션 개수 조회
+func (r *ChatRepository) CountReaction(messageID primitive.ObjectID, reaction string) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	collection := r.client.Database("chat_db").Collection("message_reactions")
+
+	count, err := collection.CountDocuments(ctx, bson.M{"message_id": messageID, "reaction": reaction})
+	if err != nil {
+		log.Printf("Error counting reaction of message %s: %v", messageID.Hex(), err)
+		return 0, err
+	}
+
+	return int(count), nil
+}
+
+// 메시지 목록의 리액션 집계 (reacted_by_me는 userID 기준)
+func (r *ChatRepository) GetReactionSummaries(messageIDs []primitive.ObjectID, userID int) (map[primitive.ObjectID][]models.ReactionSummary, error) {
+	summaries := make(map[primitive.ObjectID][]models.ReactionSummary)
+	if len(messageIDs) == 0 {
+		return summaries, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	collection := r.client.Database("chat_db").Collection("message_reactions")
+
+	pipeline := mongo.Pipeline{
+		bson.D{{Key: "$match", Value: bson.M{"message_id": bson.M{"$in": messageIDs}}}},
+		bson.D{{Key: "$group", Value: bson.M{
+			"_id":      bson.M{"message_id": "$message_id", "reaction": "$reaction"},
+			"count":    bson.M{"$sum": 1},
+			"user_ids": bson.M{"$push": "$user_id"},
+			"first_at": bson.M{"$min": "$created_at"},
+		}}},
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "first_at", Value: 1}}}},
+	}
+
+	cursor, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		log.Printf("Error aggregating reactions: %v", err)
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var group struct {
+			ID struct {
+				MessageID primitive.ObjectID `bson:"message_id"`
+				Reaction  string             `bson:"reaction"`
+			} `bson:"_id"`
+			Count   int   `bson:"count"`
+			UserIDs []int `bson:"user_ids"`
+		}
+		if err := cursor.Decode(&group); err != nil {
+			log.Printf("Error decoding reaction summary: %v", err)
+			continue
+		}
+
+		reactedByMe := false
+		for _, id := range group.UserIDs {
+			if id == userID {
+				reactedByMe = true
+				break
+			}
+		}
+
+		summaries[group.ID.MessageID] = append(summaries[group.ID.MessageID], models.ReactionSummary{
+			Reaction:    group.ID.Reaction,
+			Count:       group.Count,
+			ReactedByMe: reactedByMe,
+		})
+	}
+
+	return summaries, nil
+}
+
+// AttachReactions - 메시지 목록에 리액션 집계 추가
+func (r *ChatRepository) AttachReactions(messages []*models.Chat, userID int) error {
+	messageIDs := make([]primitive.ObjectID, 0, len(messages))
+	for _, message := range messages {
+		messageIDs = append(messageIDs, message.MessageId)
+	}
+
+	summaries, err := r.GetReactionSummaries(messageIDs, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, message := range messages {
+		message.Reactions = summaries[message.MessageId]
+	}
+
+	return nil
+}
+
+// DeleteReactionsByRoomID deletes all reactions for a room
+func (r *ChatRepository) DeleteReactionsByRoomID(roomID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	collection := r.client.Database("chat_db").Collection("message_reactions")
+	_, err := collection.DeleteMany(ctx, bson.M{"room_id": roomID})
+	return err
+}
+
+// DeleteReactionsByMessageID deletes all reactions for a message
+func (r *ChatRepository) DeleteReactionsByMessageID(messageID primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	collection := r.client.Database("chat_db").Collection("message_reactions")
+	_, err := collection.DeleteMany(ctx, bson.M{"message_id": messageID})
+	return err
+}
@@ -0,0 +1,96 @@
+package repo
+
+import (
+	"context"
+	"log"
+	"time"
+	"unicode/utf8"
+
+	"solo/pkg/models"
+	"solo/pkg/types/commontype"
+
+	"github.com/samber/lo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// BuildReplyPreview - 인용된 메시지로 미리보기 생성 (quoted가 nil이면 남아있지 않은 메시지)
+func BuildReplyPreview(messageID primitive.ObjectID, quoted *models.Chat, room *models.ChatRoom) *models.ReplyPreview {
+	preview := &models.ReplyPreview{MessageID: messageID}
+	if quoted == nil || quoted.DeletedAt != nil {
+		preview.Deleted = true
+		if quoted != nil {
+			preview.Type = quoted.Type
+			preview.SenderID = quoted.SenderID
+			preview.CharacterName = characterName(room, quoted.SenderID)
+		}
+		return preview
+	}
+
+	preview.Type = quoted.Type
+	preview.SenderID = quoted.SenderID
+	preview.CharacterName = characterName(room, quoted.SenderID)
+
+	snippet := quoted.Message
+	if quoted.Type == commontype.ChatTypeImage {
+		snippet = "사진"
+	}
+	if utf8.RuneCountInString(snippet) > commontype.ReplySnippetLength {
+		snippet = string([]rune(snippet)[:commontype.ReplySnippetLength]) + "…"
+	}
+	preview.Snippet = snippet
+
+	return preview
+}
+
+func characterName(room *models.ChatRoom, userID int) string {
+	gamer, ok := lo.Find(room.Gamers, func(g models.GamerInfo) bool { return g.UserID == userID })
+	if !ok {
+		return ""
+	}
+	return gamer.CharacterName
+}
+
+// AttachReplyPreviews - 답장 메시지 목록에 인용 미리보기 추가 (조회 시점의 수정/삭제 반영)
+func (r *ChatRepository) AttachReplyPreviews(messages []*models.Chat, room *models.ChatRoom) error {
+	var replyIDs []primitive.ObjectID
+	for _, message := range messages {
+		if !message.ReplyTo.IsZero() {
+			replyIDs = append(replyIDs, message.ReplyTo)
+		}
+	}
+	if len(replyIDs) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	collection := r.client.Database("chat_db").Collection("messages")
+
+	cursor, err := collection.Find(ctx, bson.M{"_id": bson.M{"$in": lo.Uniq(replyIDs)}, "room_id": room.ID})
+	if err != nil {
+		log.Printf("Error finding quoted messages: %v", err)
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	var quotedMessages []models.Chat
+	if err := cursor.All(ctx, &quotedMessages); err != nil {
+		log.Printf("Error decoding quoted messages: %v", err)
+		return err
+	}
+
+	quotedByID := make(map[primitive.ObjectID]*models.Chat, len(quotedMessages))
+	for i := range quotedMessages {
+		quotedByID[quotedMessages[i].MessageId] = &quotedMessages[i]
+	}
+
+	for _, message := range messages {
+		if !message.ReplyTo.IsZero() {
+			message.ReplyPreview = BuildReplyPreview(message.ReplyTo, quotedByID[message.ReplyTo], room)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package repo
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"solo/pkg/models"
+	"solo/pkg/types/commontype"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestBuildReplyPreview(t *testing.T) {
+	messageID := primitive.NewObjectID()
+	room := &models.ChatRoom{Gamers: []models.GamerInfo{{UserID: 1, CharacterName: "호랑이"}}}
+
+	t.Run("남아있지 않은 메시지", func(t *testing.T) {
+		preview := BuildReplyPreview(messageID, nil, room)
+		if !preview.Deleted || preview.MessageID != messageID || preview.SenderID != 0 {
+			t.Errorf("preview = %+v, want deleted with only message ID", preview)
+		}
+	})
+
+	t.Run("보내기 취소된 메시지는 내용 없이 발신자만", func(t *testing.T) {
+		deletedAt := time.Now()
+		quoted := &models.Chat{Type: commontype.ChatTypeChat, SenderID: 1, DeletedAt: &deletedAt,
+			Unsent: &models.UnsentContent{Message: "취소한 메시지"}}
+
+		preview := BuildReplyPreview(messageID, quoted, room)
+		if !preview.Deleted || preview.Snippet != "" {
+			t.Errorf("preview = %+v, want deleted without snippet", preview)
+		}
+		if preview.CharacterName != "호랑이" {
+			t.Errorf("character = %q, want 호랑이", preview.CharacterName)
+		}
+	})
+
+	t.Run("긴 메시지는 글자 수 기준으로 자름", func(t *testing.T) {
+		quoted := &models.Chat{Type: commontype.ChatTypeChat, SenderID: 1, Message: strings.Repeat("가", commontype.ReplySnippetLength+10)}
+
+		snippet := BuildReplyPreview(messageID, quoted, room).Snippet
+		if !strings.HasSuffix(snippet, "…") || utf8.RuneCountInString(snippet) != commontype.ReplySnippetLength+1 {
+			t.Errorf("snippet = %q (%d runes), want %d runes and ellipsis", snippet, utf8.RuneCountInString(snippet), commontype.ReplySnippetLength+1)
+		}
+		if !utf8.ValidString(snippet) {
+			t.Errorf("snippet cut inside a character: %q", snippet)
+		}
+	})
+
+	t.Run("이미지 메시지", func(t *testing.T) {
+		preview := BuildReplyPreview(messageID, &models.Chat{Type: commontype.ChatTypeImage, SenderID: 1}, room)
+		if preview.Snippet != "사진" || preview.Type != commontype.ChatTypeImage {
+			t.Errorf("preview = %+v, want image snippet", preview)
+		}
+	})
+
+	t.Run("방에 없는 참가자", func(t *testing.T) {
+		preview := BuildReplyPreview(messageID, &models.Chat{Type: commontype.ChatTypeChat, SenderID: 2, Message: "하이"}, room)
+		if preview.CharacterName != "" || preview.Snippet != "하이" || preview.Deleted {
+			t.Errorf("preview = %+v, want snippet without character name", preview)
+		}
+	})
+}
@@ -0,0 +1,177 @@
+package repo
+
+import (
+	"context"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	"solo/pkg/models"
+
+	"github.com/samber/lo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// searchWords: 소문자로 바꾸고 글자/숫자가 아닌 문자로 단어 분리
+func searchWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// isNgramWord: 띄어쓰기만으로 검색하기 어려운 문자(한글, 한자, 가나)가 포함된 단어인지 확인
+func isNgramWord(word string) bool {
+	for _, r := range word {
+		if unicode.In(r, unicode.Hangul, unicode.Han, unicode.Hiragana, unicode.Katakana) {
+			return true
+		}
+	}
+	return false
+}
+
+// runeNgrams: 단어의 n글자 조각 목록
+func runeNgrams(word string, n int) []string {
+	runes := []rune(word)
+	if len(runes) < n {
+		return nil
+	}
+	ngrams := make([]string, 0, len(runes)-n+1)
+	for i := 0; i+n <= len(runes); i++ {
+		ngrams = append(ngrams, string(runes[i:i+n]))
+	}
+	return ngrams
+}
+
+// searchTokens: 저장용 검색 토큰 (단어 + 한글 등은 1, 2글자 조각)
+// 조사가 붙은 한글 단어("커피를")도 부분 검색("커피")이 되도록 n-gram을 함께 저장
+func searchTokens(text string) []string {
+	var tokens []string
+	for _, word := range searchWords(text) {
+		tokens = append(tokens, word)
+		if isNgramWord(word) {
+			tokens = append(tokens, runeNgrams(word, 1)...)
+			tokens = append(tokens, runeNgrams(word, 2)...)
+		}
+	}
+	return lo.Uniq(tokens)
+}
+
+// queryTokens: 검색어를 저장 토큰과 같은 방식으로 분리 (한글 등은 2글자 조각, 한 글자면 그대로)
+func queryTokens(query string) []string {
+	var tokens []string
+	for _, word := range searchWords(query) {
+		if isNgramWord(word) && len([]rune(word)) > 1 {
+			tokens = append(tokens, runeNgrams(word, 2)...)
+			continue
+		}
+		tokens = append(tokens, word)
+	}
+	return lo.Uniq(tokens)
+}
+
+// 방 메시지 검색 (최신순), beforeID가 있으면 그 메시지보다 오래된 결과만 조회
+// 텍스트 인덱스로 후보를 좁힌 뒤 검색어 전체가 포함된 메시지만 반환
+func (r *ChatRepository) SearchMessages(roomID string, userID int, query string, beforeID primitive.ObjectID, limit int) ([]*models.Chat, bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	tokens := queryTokens(query)
+	if len(tokens) == 0 {
+		return nil, false, nil
+	}
+
+	filter := visibleMessageFilter(roomID, userID)
+	filter["$text"] = bson.M{"$search": strings.Join(tokens, " ")}
+	filter["message"] = bson.M{"$regex": regexp.QuoteMeta(strings.TrimSpace(query)), "$options": "i"}
+
+	if !beforeID.IsZero() {
+		cursor, err := r.findCursorMessage(ctx, roomID, beforeID)
+		if err != nil {
+			return nil, false, err
+		}
+		applyCursor(filter, cursor, CursorBefore)
+	}
+
+	collection := r.client.Database("chat_db").Collection("messages")
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetLimit(int64(limit + 1))
+
+	findCursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		log.Printf("Error searching messages in room %s: %v", roomID, err)
+		return nil, false, err
+	}
+	defer findCursor.Close(ctx)
+
+	var messages []*models.Chat
+	if err := findCursor.All(ctx, &messages); err != nil {
+		log.Printf("Error decoding searched messages in room %s: %v", roomID, err)
+		return nil, false, err
+	}
+
+	hasMore := len(messages) > limit
+	if hasMore {
+		messages = messages[:limit]
+	}
+
+	return messages, hasMore, nil
+}
+
+// 검색 토큰 도입 전에 저장된 메시지에 토큰 추가 (afterID 이후 _id 순으로 한 번에 batchSize개)
+// _id 순으로 이어서 조회하므로 전체를 한 번만 훑음, 처리한 수와 마지막 메시지 ID 반환
+func (r *ChatRepository) BackfillSearchTokens(afterID primitive.ObjectID, batchSize int) (int, primitive.ObjectID, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	collection := r.client.Database("chat_db").Collection("messages")
+	filter := bson.M{
+		"_id":           bson.M{"$gt": afterID},
+		"search_tokens": bson.M{"$exists": false},
+		"deleted_at":    bson.M{"$exists": false},
+	}
+	opts := options.Find().
+		SetProjection(bson.M{"message": 1}).
+		SetSort(bson.D{{Key: "_id", Value: 1}}).
+		SetLimit(int64(batchSize))
+
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		log.Printf("Error finding messages without search tokens: %v", err)
+		return 0, afterID, err
+	}
+	defer cursor.Close(ctx)
+
+	var messages []models.Chat
+	if err := cursor.All(ctx, &messages); err != nil {
+		log.Printf("Error decoding messages without search tokens: %v", err)
+		return 0, afterID, err
+	}
+	if len(messages) == 0 {
+		return 0, afterID, nil
+	}
+
+	writes := make([]mongo.WriteModel, 0, len(messages))
+	for _, message := range messages {
+		tokens := searchTokens(message.Message)
+		if tokens == nil {
+			tokens = []string{}
+		}
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": message.MessageId}).
+			SetUpdate(bson.M{"$set": bson.M{"search_tokens": tokens}}))
+	}
+
+	_, err = collection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		log.Printf("Error backfilling search tokens: %v", err)
+		return 0, afterID, err
+	}
+
+	return len(messages), messages[len(messages)-1].MessageId, nil
+}
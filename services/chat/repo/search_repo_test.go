@@ -0,0 +1,73 @@
+package repo
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/samber/lo"
+)
+
+func TestRuneNgrams(t *testing.T) {
+	if got := strings.Join(runeNgrams("커피를", 2), ","); got != "커피,피를" {
+		t.Errorf("runeNgrams(커피를, 2) = %s, want 커피,피를", got)
+	}
+	if got := runeNgrams("밥", 2); got != nil {
+		t.Errorf("runeNgrams(밥, 2) = %q, want nil", got)
+	}
+}
+
+func TestSearchTokens(t *testing.T) {
+	tokens := searchTokens("오늘 Coffee, 커피를!")
+
+	for _, want := range []string{"오늘", "오", "늘", "coffee", "커피를", "커피", "피를", "를"} {
+		if !lo.Contains(tokens, want) {
+			t.Errorf("searchTokens missing %q: %q", want, tokens)
+		}
+	}
+	// 영어는 n-gram 없이 단어만, 특수문자는 제외
+	for _, unwanted := range []string{"co", "Coffee", "커피를!"} {
+		if lo.Contains(tokens, unwanted) {
+			t.Errorf("searchTokens contains %q: %q", unwanted, tokens)
+		}
+	}
+	if len(tokens) != len(lo.Uniq(tokens)) {
+		t.Errorf("searchTokens has duplicates: %q", tokens)
+	}
+
+	if tokens := searchTokens("  !? "); len(tokens) != 0 {
+		t.Errorf("searchTokens(punctuation) = %q, want none", tokens)
+	}
+}
+
+// 검색어 토큰은 항상 저장 토큰에 포함되어야 부분 검색이 됨
+func TestQueryTokensMatchStoredTokens(t *testing.T) {
+	messages := map[string][]string{
+		"커피를 마셨어요":          {"커피", "마셨", "피를", "셨어요", "밥"},
+		"내일 영화 보러 갈래":       {"영화", "보러 갈", "일"},
+		"Let's grab coffee": {"coffee", "GRAB", "let"},
+	}
+
+	for message, queries := range messages {
+		stored := searchTokens(message)
+		for _, query := range queries {
+			if !strings.Contains(strings.ToLower(message), strings.ToLower(query)) {
+				continue
+			}
+			for _, token := range queryTokens(query) {
+				if !lo.Contains(stored, token) {
+					t.Errorf("query %q token %q not stored for %q (stored %q)", query, token, message, stored)
+				}
+			}
+		}
+	}
+}
+
+func TestQueryTokensSkipsSingleRuneNgrams(t *testing.T) {
+	// 한 글자 조각으로 검색하면 후보가 너무 많아지므로 2글자 조각만 사용
+	if got := queryTokens("커피를"); strings.Join(got, ",") != "커피,피를" {
+		t.Errorf("queryTokens(커피를) = %q, want 커피, 피를", got)
+	}
+	if got := queryTokens("밥"); len(got) != 1 || got[0] != "밥" {
+		t.Errorf("queryTokens(밥) = %q, want 밥", got)
+	}
+}
@@ -0,0 +1,238 @@
+package service
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"solo/pkg/config"
+	"solo/pkg/media"
+	"solo/pkg/models"
+	"solo/pkg/types/commontype"
+)
+
+// 방 데이터 보관 여부 (ROOM_ARCHIVE_ENABLED=false면 보관 없이 삭제)
+func roomArchiveEnabled() bool {
+	return config.GetString("ROOM_ARCHIVE_ENABLED", "true") != "false"
+}
+
+// 정리 전에 방 데이터와 이미지를 보관 (다시 실행하면 덮어씀)
+// 방 문서가 없어도 남아 있는 메시지 등은 보관, 이전에 만든 보관 데이터가 있으면 유지
+func (s *ChatService) ArchiveRoom(roomID string) error {
+	room, err := s.chatRepo.GetRoomByID(roomID)
+	if err != nil {
+		return err
+	}
+	if room == nil {
+		existing, err := s.chatRepo.GetRoomArchiveByRoomID(roomID)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			log.Printf("⚠️ Room %s not found, keeping existing archive", roomID)
+			return nil
+		}
+
+		log.Printf("⚠️ Room %s not found, archiving remaining data only", roomID)
+		room = &models.ChatRoom{ID: roomID}
+	}
+
+	// 정리 단계에서 원본 이미지가 지워지므로 보관 기간 동안 남도록 복사
+	mediaKeys, err := s.archiveRoomMedia(roomID)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	messageCount, err := s.chatRepo.WriteRoomArchiveJSON(roomID, gz)
+	if err != nil {
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	archive := &models.RoomArchive{
+		RoomID:       room.ID,
+		RoomSeq:      room.Seq,
+		RoomType:     room.Type,
+		UserIDs:      room.UserIDs,
+		MessageCount: messageCount,
+		Size:         buf.Len(),
+		MediaKeys:    mediaKeys,
+		ArchivedAt:   now,
+		ExpireAt:     now.Add(config.GetDuration("ROOM_ARCHIVE_RETENTION", commontype.RoomArchiveRetention)),
+	}
+
+	// MongoDB 문서 크기 제한을 넘지 않도록 큰 보관 데이터는 파일로 저장
+	if buf.Len() > commontype.RoomArchiveInlineMaxBytes {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
+
+		archive.ObjectKey = fmt.Sprintf("archives/%s.json.gz", room.ID)
+		if err := s.mediaStore.Put(ctx, archive.ObjectKey, "application/gzip", buf.Bytes()); err != nil {
+			return err
+		}
+	} else {
+		archive.Data = buf.Bytes()
+	}
+
+	if err := s.chatRepo.UpsertRoomArchive(archive); err != nil {
+		return err
+	}
+
+	log.Printf("📦 Room %s archived (%d messages, %d media objects, %d bytes)", room.ID, messageCount, len(mediaKeys), archive.Size)
+	return nil
+}
+
+// archiveRoomMedia - 방 이미지 원본/썸네일을 archives/ 아래로 복사하고 복사한 키 반환
+func (s *ChatService) archiveRoomMedia(roomID string) ([]string, error) {
+	mediaList, err := s.chatRepo.GetChatMediaByRoomID(roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	for _, chatMedia := range mediaList {
+		objects := []struct{ key, contentType string }{
+			{chatMedia.ObjectKey, chatMedia.ContentType},
+			{chatMedia.ThumbnailKey, "image/jpeg"},
+		}
+		for _, object := range objects {
+			archiveKey := "archives/" + object.key
+			copied, err := s.copyMediaObject(object.key, archiveKey, object.contentType)
+			if err != nil {
+				return nil, fmt.Errorf("failed to archive media object %s: %w", object.key, err)
+			}
+			if copied {
+				keys = append(keys, archiveKey)
+			}
+		}
+	}
+	return keys, nil
+}
+
+// copyMediaObject - 저장소 객체 복사, 원본이 이미 없으면 false
+func (s *ChatService) copyMediaObject(srcKey, dstKey, contentType string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	reader, err := s.mediaStore.Open(ctx, srcKey)
+	if errors.Is(err, media.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return false, err
+	}
+
+	if err := s.mediaStore.Put(ctx, dstKey, contentType, data); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// 방 ID로 보관 데이터 조회, 없으면 nil
+func (s *ChatService) GetRoomArchive(roomID string) (*models.RoomArchive, error) {
+	return s.chatRepo.GetRoomArchiveByRoomID(roomID)
+}
+
+// 방 Seq로 보관 데이터 조회, 없으면 nil
+func (s *ChatService) GetRoomArchiveBySeq(seq int64) (*models.RoomArchive, error) {
+	return s.chatRepo.GetRoomArchiveBySeq(seq)
+}
+
+// 보관 데이터의 압축을 풀어 JSON으로 읽기
+func (s *ChatService) OpenRoomArchive(ctx context.Context, archive *models.RoomArchive) (io.ReadCloser, error) {
+	var compressed io.ReadCloser = io.NopCloser(bytes.NewReader(archive.Data))
+	if archive.ObjectKey != "" {
+		obj, err := s.mediaStore.Open(ctx, archive.ObjectKey)
+		if err != nil {
+			return nil, err
+		}
+		compressed = obj
+	}
+
+	gz, err := gzip.NewReader(compressed)
+	if err != nil {
+		compressed.Close()
+		return nil, err
+	}
+
+	return &archiveReader{Reader: gz, closers: []io.Closer{gz, compressed}}, nil
+}
+
+type archiveReader struct {
+	io.Reader
+	closers []io.Closer
+}
+
+func (r *archiveReader) Close() error {
+	var firstErr error
+	for _, c := range r.closers {
+		if err := c.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// 보관 기간이 지난 데이터 삭제 워커
+func (s *ChatService) RunArchivePurgeWorker() {
+	ticker := time.NewTicker(commontype.RoomArchivePurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		s.purgeExpiredArchives()
+		<-ticker.C
+	}
+}
+
+func (s *ChatService) purgeExpiredArchives() {
+	for {
+		archives, err := s.chatRepo.GetExpiredRoomArchives(time.Now(), 100)
+		if err != nil {
+			log.Printf("Failed to get expired room archives: %v", err)
+			return
+		}
+		if len(archives) == 0 {
+			return
+		}
+
+		for _, archive := range archives {
+			objectKeys := archive.MediaKeys
+			if archive.ObjectKey != "" {
+				objectKeys = append(objectKeys, archive.ObjectKey)
+			}
+
+			for _, key := range objectKeys {
+				ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+				err := s.mediaStore.Delete(ctx, key)
+				cancel()
+				if err != nil {
+					// 파일 삭제에 실패하면 다음 주기에 다시 시도
+					log.Printf("Failed to delete archive object %s: %v", key, err)
+					return
+				}
+			}
+
+			if err := s.chatRepo.DeleteRoomArchive(archive.ID); err != nil {
+				log.Printf("Failed to delete room archive %s: %v", archive.RoomID, err)
+				return
+			}
+			log.Printf("🗑️ Room archive %s purged (expired at %s)", archive.RoomID, archive.ExpireAt.Format(time.RFC3339))
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"testing"
+
+	"solo/pkg/media"
+	"solo/pkg/models"
+)
+
+func gzipped(t *testing.T, data string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err := gz.Write([]byte(data)); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func newArchiveTestService(t *testing.T) (*ChatService, media.Store) {
+	t.Helper()
+	store, err := media.NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &ChatService{mediaStore: store}, store
+}
+
+// 보관이 켜져 있으면 삭제 단계보다 먼저 보관
+func TestCleanupStepsArchiveFirst(t *testing.T) {
+	t.Setenv("ROOM_ARCHIVE_ENABLED", "")
+
+	names := cleanupStepNames((&ChatService{}).cleanupSteps())
+	if names[0] != "archive" {
+		t.Errorf("steps = %v, want archive first", names)
+	}
+}
+
+func TestOpenRoomArchive(t *testing.T) {
+	s, store := newArchiveTestService(t)
+	ctx := context.Background()
+
+	read := func(archive *models.RoomArchive) string {
+		t.Helper()
+		reader, err := s.OpenRoomArchive(ctx, archive)
+		if err != nil {
+			t.Fatalf("OpenRoomArchive() error = %v", err)
+		}
+		defer reader.Close()
+		data, err := io.ReadAll(reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+
+	// 문서에 직접 보관한 경우
+	if got := read(&models.RoomArchive{Data: gzipped(t, `{"room":null}`)}); got != `{"room":null}` {
+		t.Errorf("inline archive = %s", got)
+	}
+
+	// 크기가 커서 저장소에 보관한 경우
+	key := "archives/rooms/room.json.gz"
+	if err := store.Put(ctx, key, "application/gzip", gzipped(t, `{"room":{"id":"room"}}`)); err != nil {
+		t.Fatal(err)
+	}
+	if got := read(&models.RoomArchive{ObjectKey: key}); got != `{"room":{"id":"room"}}` {
+		t.Errorf("stored archive = %s", got)
+	}
+
+	if _, err := s.OpenRoomArchive(ctx, &models.RoomArchive{Data: []byte("not gzip")}); err == nil {
+		t.Error("OpenRoomArchive() accepted corrupt data")
+	}
+}
+
+func TestCopyMediaObject(t *testing.T) {
+	s, store := newArchiveTestService(t)
+	ctx := context.Background()
+
+	// 이미 지워진 원본은 건너뜀
+	copied, err := s.copyMediaObject("rooms/room/missing.jpg", "archives/rooms/room/missing.jpg", "image/jpeg")
+	if err != nil || copied {
+		t.Errorf("missing source: copied=%t err=%v, want skipped", copied, err)
+	}
+
+	if err := store.Put(ctx, "rooms/room/photo.jpg", "image/jpeg", []byte("jpeg")); err != nil {
+		t.Fatal(err)
+	}
+	copied, err = s.copyMediaObject("rooms/room/photo.jpg", "archives/rooms/room/photo.jpg", "image/jpeg")
+	if err != nil || !copied {
+		t.Fatalf("copy: copied=%t err=%v", copied, err)
+	}
+
+	reader, err := store.Open(ctx, "archives/rooms/room/photo.jpg")
+	if err != nil {
+		t.Fatalf("archived copy missing: %v", err)
+	}
+	defer reader.Close()
+	if data, _ := io.ReadAll(reader); string(data) != "jpeg" {
+		t.Errorf("archived copy = %q, want original bytes", data)
+	}
+}
@@ -4,10 +4,14 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"sort"
+	"strings"
 	"time"
 
+	"solo/pkg/config"
 	"solo/pkg/dto"
 	"solo/pkg/logger"
+	"solo/pkg/media"
 	"solo/pkg/models"
 	"solo/pkg/redis"
 	"solo/pkg/types/commontype"
@@ -17,6 +21,7 @@ import (
 
 	"github.com/samber/lo"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 type MQEmitter interface {
@@ -32,14 +37,16 @@ type ChatService struct {
 	userRepo    *repository.UserRepository
 	redisClient *redis.RedisClient
 	emitter     MQEmitter
+	mediaStore  media.Store
 }
 
-func NewChatService(chatRepo *repo.ChatRepository, userRepo *repository.UserRepository, redisClient *redis.RedisClient, emitter MQEmitter) *ChatService {
+func NewChatService(chatRepo *repo.ChatRepository, userRepo *repository.UserRepository, redisClient *redis.RedisClient, emitter MQEmitter, mediaStore media.Store) *ChatService {
 	return &ChatService{
 		chatRepo:    chatRepo,
 		userRepo:    userRepo,
 		redisClient: redisClient,
 		emitter:     emitter,
+		mediaStore:  mediaStore,
 	}
 }
 
@@ -76,10 +83,12 @@ func (s *ChatService) CreateRoom(matchEvent eventtypes.MatchEvent) error {
 	female := 0
 
 	for _, user := range matchEvent.MatchedUsers {
-		var gamer models.GamerInfo
+		gamer := models.GamerInfo{
+			UserID: user.ID,
+			Gender: user.Gender,
+		}
 
 		if matchEvent.MatchType == commontype.MATCH_GAME {
-			gamer.UserID = user.ID
 			if user.Gender == commontype.MALE {
 				gamer.CharacterID = male
 				gamer.CharacterName = commontype.MaleNames[male]
@@ -111,6 +120,8 @@ func (s *ChatService) CreateRoom(matchEvent eventtypes.MatchEvent) error {
 		CreatedAt:           startTime,
 		FinishChatAt:        finishTime,
 		FinishFinalChoiceAt: finishTime.Add(commontype.FinishFinalChoiceTimer),
+		RevealPolicy:        revealPolicyOf(matchEvent),
+		Timeline:            buildRoomTimeline(matchEvent.MatchType, startTime, finishTime),
 		ModifiedAt:          startTime,
 	}
 
@@ -135,18 +146,34 @@ func (s *ChatService) CreateRoom(matchEvent eventtypes.MatchEvent) error {
 		return err
 	}
 
-	// Redis에 타임아웃 설정
-	err = s.redisClient.SetRoomTimeout(room.ID, time.Until(room.FinishChatAt))
+	// 게임 서비스의 메시지 검열에서 사용하는 방 타입
+	err = s.redisClient.SetRoomType(room.ID, room.Type)
+	if err != nil {
+		log.Printf("Failed to set room type in Redis: %v", err)
+		return err
+	}
+
+	// Redis에 타임아웃 설정 (커플 채팅방은 최종 선택 흐름이 없으므로 별도 타이머 사용)
+	if room.Type == commontype.MATCH_COUPLE {
+		err = s.redisClient.SetCoupleRoomTimeout(room.ID, time.Until(room.FinishChatAt))
+	} else {
+		err = s.redisClient.SetRoomTimeout(room.ID, time.Until(room.FinishChatAt))
+	}
 	if err != nil {
 		log.Printf("Failed to set room timeout in Redis: %v", err)
 		return err
 	}
 
-	// 밸런스 게임 타이머 설정 (15분)
-	if matchEvent.MatchType == commontype.MATCH_GAME {
-		err = s.redisClient.SetBalanceGameTimer(room.ID, commontype.BalanceGameStartTimer)
+	// 타임라인에 따라 방 활동 (밸런스 게임 등) 예약
+	for i, entry := range room.Timeline {
+		err = s.redisClient.ScheduleActivityJob(commontype.ActivityJob{
+			Activity: entry.Activity,
+			Phase:    commontype.ActivityPhaseStart,
+			RoomID:   room.ID,
+			Slot:     i,
+		}, entry.StartAt)
 		if err != nil {
-			log.Printf("Failed to set balance game timer: %v", err)
+			log.Printf("Failed to schedule activity %s: %v", entry.Activity, err)
 			return err
 		}
 	}
@@ -180,6 +207,61 @@ func extractUserIDs(users []commontype.WaitingUser) []int {
 	return ids
 }
 
+// buildRoomTimeline: 게임 모드별 타임라인을 방 시작 시각 기준으로 변환
+// 환경 변수 형식: "balance_game@10m,balance_game@25m"
+func buildRoomTimeline(matchType int, startTime, finishTime time.Time) []models.TimelineEntry {
+	envKey := "GAME_ROOM_TIMELINE"
+	if matchType == commontype.MATCH_COUPLE {
+		envKey = "COUPLE_ROOM_TIMELINE"
+	}
+
+	slots := commontype.DefaultRoomTimelines[matchType]
+	if values := config.GetStringList(envKey, nil); values != nil {
+		slots = parseTimelineSlots(envKey, values)
+	}
+
+	// 첫인상 선택 라운드는 오프셋이 설정된 게임방에만 추가
+	if matchType == commontype.MATCH_GAME {
+		if offset := config.GetDuration("FIRST_IMPRESSION_OFFSET", 0); offset > 0 {
+			slots = append(slots, commontype.TimelineSlot{Activity: commontype.ActivityFirstImpression, Offset: offset})
+		}
+	}
+
+	timeline := []models.TimelineEntry{}
+	for _, slot := range slots {
+		startAt := startTime.Add(slot.Offset)
+		// 대화 종료 이후의 활동은 예약하지 않음
+		if !startAt.Before(finishTime) {
+			log.Printf("⚠️ Timeline activity %s at %v is after room finish, skipped", slot.Activity, slot.Offset)
+			continue
+		}
+
+		timeline = append(timeline, models.TimelineEntry{
+			Activity: slot.Activity,
+			StartAt:  startAt,
+		})
+	}
+
+	sort.Slice(timeline, func(i, j int) bool { return timeline[i].StartAt.Before(timeline[j].StartAt) })
+	return timeline
+}
+
+func parseTimelineSlots(envKey string, values []string) []commontype.TimelineSlot {
+	var slots []commontype.TimelineSlot
+	for _, value := range values {
+		activity, offset, found := strings.Cut(value, "@")
+		duration, err := time.ParseDuration(offset)
+		if !found || activity == "" || err != nil {
+			log.Printf("⚠️ Invalid timeline entry for %s: %s", envKey, value)
+			continue
+		}
+
+		slots = append(slots, commontype.TimelineSlot{Activity: activity, Offset: duration})
+	}
+
+	return slots
+}
+
 // 특정 유저가 속한 채팅방 목록 조회
 func (s *ChatService) GetChatRoomList(userID int) ([]models.ChatRoom, error) {
 	rooms, err := s.chatRepo.GetRoomsByUserID(userID)
@@ -190,8 +272,18 @@ func (s *ChatService) GetChatRoomList(userID int) ([]models.ChatRoom, error) {
 	return rooms, nil
 }
 
-func (s *ChatService) GetLatestMessage(roomID string) (*models.Chat, error) {
-	return s.chatRepo.GetLastMessageByRoomID(roomID)
+// 채팅방 목록 조회 (방별 마지막 메시지, 안 읽은 메시지 수, 캐릭터 정보 포함)
+func (s *ChatService) GetChatRoomSummaries(userID int) ([]models.ChatRoomSummary, error) {
+	summaries, err := s.chatRepo.GetRoomSummariesByUserID(userID)
+	if err != nil {
+		log.Printf("Failed to get chat room summaries for user %d: %v", userID, err)
+		return nil, err
+	}
+	return summaries, nil
+}
+
+func (s *ChatService) GetLatestMessage(roomID string, userID int) (*models.Chat, error) {
+	return s.chatRepo.GetLastMessageByRoomID(roomID, userID)
 }
 
 func (s *ChatService) GetUnreadCount(roomID string, userID int) (int, error) {
@@ -256,6 +348,8 @@ func (s *ChatService) GetChatRoomDetailByID(roomID string) (*dto.RoomDetailRespo
 		CreatedAt:           room.CreatedAt,
 		FinishChatAt:        room.FinishChatAt,
 		FinishFinalChoiceAt: room.FinishFinalChoiceAt,
+		RevealPolicy:        room.RevealPolicy,
+		Timeline:            room.Timeline,
 	}
 
 	return &roomDetail, nil
@@ -309,6 +403,18 @@ func (s *ChatService) HandleRoomJoin(roomID string, userID int, joinTime time.Ti
 	return nil
 }
 
+// revealPolicyOf - 매칭 이벤트에 지정된 공개 정책, 없거나 잘못된 값이면 환경 변수 기본값
+func revealPolicyOf(matchEvent eventtypes.MatchEvent) int {
+	if policy := matchEvent.RevealPolicy; policy != nil {
+		switch *policy {
+		case commontype.RevealPolicyFull, commontype.RevealPolicyMutualOnly, commontype.RevealPolicyAnonymousTally:
+			return *policy
+		}
+		log.Printf("⚠️ Invalid reveal policy %d in match %s, using default", *policy, matchEvent.MatchId)
+	}
+	return config.GetInt("FINAL_CHOICE_REVEAL_POLICY", commontype.RevealPolicyFull)
+}
+
 // 채팅방 나가기
 func (s *ChatService) LeaveChatRoom(roomID string, userID int) error {
 	roomLeaveEvent := eventtypes.RoomLeaveEvent{
@@ -362,15 +468,168 @@ func (s *ChatService) HandleChatRead(messageID primitive.ObjectID, roomID string
 }
 
 // 특정 채팅방의 메시지 목록 조회 (페이징 포함)
-func (s *ChatService) GetChatMsgListByRoomID(roomID string, pageNumber int, pageSize int) ([]*models.Chat, int64, error) {
-	messages, totalCount, err := s.chatRepo.GetByRoomIDWithPagination(roomID, pageNumber, pageSize)
+func (s *ChatService) GetChatMsgListByRoomID(roomID string, userID int, pageNumber int, pageSize int) ([]*models.Chat, int64, error) {
+	messages, totalCount, err := s.chatRepo.GetByRoomIDWithPagination(roomID, userID, pageNumber, pageSize)
 	if err != nil {
 		log.Printf("Failed to get chat messages for room %s: %v", roomID, err)
 		return nil, 0, err
 	}
+
+	s.attachMessageExtras(roomID, messages, userID)
+
 	return messages, totalCount, nil
 }
 
+// 커서 방향 및 에러 (핸들러에서 사용)
+const (
+	CursorBefore = repo.CursorBefore
+	CursorAfter  = repo.CursorAfter
+)
+
+var ErrCursorNotFound = repo.ErrCursorNotFound
+
+// 커서(메시지 ID) 기준 메시지 목록 조회, direction은 CursorBefore 또는 CursorAfter
+func (s *ChatService) GetChatMsgListByCursor(roomID string, userID int, cursorID primitive.ObjectID, direction string, limit int) ([]*models.Chat, bool, error) {
+	messages, hasMore, err := s.chatRepo.GetByRoomIDWithCursor(roomID, userID, cursorID, direction, limit)
+	if err != nil {
+		log.Printf("Failed to get chat messages %s %s for room %s: %v", direction, cursorID.Hex(), roomID, err)
+		return nil, false, err
+	}
+
+	s.attachMessageExtras(roomID, messages, userID)
+
+	return messages, hasMore, nil
+}
+
+// 채팅방 메시지 검색, 결과마다 앞뒤 메시지를 함께 반환
+func (s *ChatService) SearchChatMessages(roomID string, userID int, query string, beforeID primitive.ObjectID) ([]dto.ChatSearchHit, bool, error) {
+	messages, hasMore, err := s.chatRepo.SearchMessages(roomID, userID, query, beforeID, commontype.SearchPageSize)
+	if err != nil {
+		log.Printf("Failed to search chat messages in room %s: %v", roomID, err)
+		return nil, false, err
+	}
+
+	s.attachMessageExtras(roomID, messages, userID)
+
+	hits := make([]dto.ChatSearchHit, 0, len(messages))
+	for i, message := range messages {
+		hit := dto.ChatSearchHit{Message: message, Before: []*models.Chat{}, After: []*models.Chat{}}
+
+		// 조회 횟수를 줄이기 위해 앞쪽 결과만 앞뒤 메시지 포함 (나머지는 클라이언트가 필요할 때 커서 조회)
+		if i < commontype.SearchContextHits {
+			before, err := s.chatRepo.GetMessagesAround(message, userID, repo.CursorBefore, commontype.SearchContextSize)
+			if err != nil {
+				log.Printf("Failed to get context before message %s: %v", message.MessageId.Hex(), err)
+			} else if before != nil {
+				hit.Before = before
+			}
+
+			after, err := s.chatRepo.GetMessagesAround(message, userID, repo.CursorAfter, commontype.SearchContextSize)
+			if err != nil {
+				log.Printf("Failed to get context after message %s: %v", message.MessageId.Hex(), err)
+			} else if after != nil {
+				hit.After = after
+			}
+		}
+
+		hits = append(hits, hit)
+	}
+
+	return hits, hasMore, nil
+}
+
+// BackfillSearchTokens - 검색 토큰 도입 전 메시지에 토큰 추가 (남은 메시지가 없을 때까지)
+// 한 파드만 점유해서 실행하고, 처리한 위치를 기록해 재시작 시 이어서 처리하며, 끝나면 다시 실행하지 않음
+func (s *ChatService) BackfillSearchTokens() {
+	done, err := s.redisClient.IsSearchBackfillDone()
+	if err != nil {
+		log.Printf("❌ Search token backfill skipped: %v", err)
+		return
+	}
+	if done {
+		return
+	}
+
+	holder := primitive.NewObjectID().Hex()
+	acquired, err := s.redisClient.AcquireSearchBackfillLease(holder, commontype.SearchBackfillLease)
+	if err != nil {
+		log.Printf("❌ Search token backfill skipped: %v", err)
+		return
+	}
+	if !acquired {
+		log.Printf("🔎 Search token backfill is running on another instance")
+		return
+	}
+	defer func() {
+		if err := s.redisClient.ReleaseSearchBackfillLease(holder); err != nil {
+			log.Printf("Failed to release search backfill lease: %v", err)
+		}
+	}()
+
+	lastID := primitive.NilObjectID
+	cursor, err := s.redisClient.GetSearchBackfillCursor()
+	if err != nil {
+		log.Printf("❌ Search token backfill skipped: %v", err)
+		return
+	}
+	if cursor != "" {
+		if lastID, err = primitive.ObjectIDFromHex(cursor); err != nil {
+			log.Printf("⚠️ Invalid search backfill cursor %s, starting over: %v", cursor, err)
+			lastID = primitive.NilObjectID
+		}
+	}
+
+	total := 0
+	for {
+		renewed, err := s.redisClient.RenewSearchBackfillLease(holder, commontype.SearchBackfillLease)
+		if err != nil || !renewed {
+			log.Printf("❌ Search token backfill stopped after %d messages: lease lost (%v)", total, err)
+			return
+		}
+
+		count, nextID, err := s.chatRepo.BackfillSearchTokens(lastID, commontype.SearchBackfillBatchSize)
+		if err != nil {
+			log.Printf("❌ Search token backfill stopped after %d messages: %v", total, err)
+			return
+		}
+		if count == 0 {
+			break
+		}
+		total += count
+		lastID = nextID
+
+		if err := s.redisClient.SetSearchBackfillCursor(lastID.Hex()); err != nil {
+			log.Printf("Failed to save search backfill cursor: %v", err)
+		}
+	}
+
+	if err := s.redisClient.MarkSearchBackfillDone(); err != nil {
+		log.Printf("Failed to mark search backfill done: %v", err)
+	}
+
+	log.Printf("🔎 Search tokens backfilled for %d messages", total)
+}
+
+// 메시지 목록에 답장 미리보기와 리액션 집계 추가
+func (s *ChatService) attachMessageExtras(roomID string, messages []*models.Chat, userID int) {
+	// 답장 인용 미리보기 추가 (실패해도 메시지 목록은 반환)
+	room, err := s.chatRepo.GetRoomByID(roomID)
+	if err != nil {
+		log.Printf("Failed to get chat room %s for reply previews: %v", roomID, err)
+	} else if room != nil {
+		err = s.chatRepo.AttachReplyPreviews(messages, room)
+		if err != nil {
+			log.Printf("Failed to attach reply previews for room %s: %v", roomID, err)
+		}
+	}
+
+	// 리액션 집계 추가 (실패해도 메시지 목록은 반환)
+	err = s.chatRepo.AttachReactions(messages, userID)
+	if err != nil {
+		log.Printf("Failed to attach reactions for room %s: %v", roomID, err)
+	}
+}
+
 func (s *ChatService) UpdateChatRoomStatus(roomID string, status int) error {
 	return s.chatRepo.UpdateChatRoomStatus(roomID, status)
 }
@@ -398,6 +657,19 @@ func (s *ChatService) IsUserInRoom(userID int, roomID string) (bool, error) {
 	return lo.Contains(room.UserIDs, userID), nil
 }
 
+// 밸런스 게임 폼이 속한 방의 참가자인지 확인
+func (s *ChatService) IsUserInBalanceFormRoom(userID int, formID primitive.ObjectID) (bool, error) {
+	roomID, err := s.chatRepo.GetRoomIdByBalanceFormID(formID)
+	if err == mongo.ErrNoDocuments {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return s.IsUserInRoom(userID, roomID)
+}
+
 // 밸런스 게임 폼 삽입
 func (s *ChatService) InsertBalanceForm(form *models.BalanceGameForm) (primitive.ObjectID, error) {
 	return s.chatRepo.InsertBalanceForm(form)
@@ -525,3 +797,8 @@ func (s *ChatService) DeleteBalanceFormsByRoomID(roomID string) error {
 func (s *ChatService) DeleteMessageReaders(roomID string) error {
 	return s.chatRepo.DeleteMessageReaders(roomID)
 }
+
+// DeleteReactionsByRoomID deletes all reactions for a room
+func (s *ChatService) DeleteReactionsByRoomID(roomID string) error {
+	return s.chatRepo.DeleteReactionsByRoomID(roomID)
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"solo/pkg/types/commontype"
+)
+
+func TestParseTimelineSlots(t *testing.T) {
+	if slots := parseTimelineSlots("TEST_TIMELINE", nil); slots != nil {
+		t.Errorf("parseTimelineSlots(nil) = %+v, want nil", slots)
+	}
+
+	slots := parseTimelineSlots("TEST_TIMELINE", []string{
+		"balance_game@5m",
+		"balance_game",      // 오프셋 없음
+		"@5m",               // 활동 이름 없음
+		"balance_game@soon", // 잘못된 시간
+		"first_impression@12m30s",
+	})
+	if len(slots) != 2 {
+		t.Fatalf("parseTimelineSlots() = %+v, want 2 valid slots", slots)
+	}
+	if slots[0].Activity != "balance_game" || slots[0].Offset != 5*time.Minute {
+		t.Errorf("slots[0] = %+v, want balance_game@5m", slots[0])
+	}
+	if slots[1].Activity != "first_impression" || slots[1].Offset != 12*time.Minute+30*time.Second {
+		t.Errorf("slots[1] = %+v, want first_impression@12m30s", slots[1])
+	}
+}
+
+func TestBuildRoomTimeline(t *testing.T) {
+	t.Setenv("FIRST_IMPRESSION_OFFSET", "")
+	t.Setenv("COUPLE_ROOM_TIMELINE", "")
+	// 순서가 섞여 있고 마지막 항목은 방 종료 이후
+	t.Setenv("GAME_ROOM_TIMELINE", "balance_game@30m, balance_game@10m, balance_game@2h")
+
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	finish := start.Add(time.Hour)
+
+	timeline := buildRoomTimeline(commontype.MATCH_GAME, start, finish)
+	if len(timeline) != 2 {
+		t.Fatalf("timeline = %+v, want 2 entries before finish", timeline)
+	}
+	if !timeline[0].StartAt.Equal(start.Add(10*time.Minute)) || !timeline[1].StartAt.Equal(start.Add(30*time.Minute)) {
+		t.Errorf("timeline = %+v, want entries at +10m and +30m in order", timeline)
+	}
+
+	// 커플방 기본 타임라인은 비어 있음
+	if timeline := buildRoomTimeline(commontype.MATCH_COUPLE, start, finish); len(timeline) != 0 {
+		t.Errorf("couple timeline = %+v, want empty", timeline)
+	}
+}
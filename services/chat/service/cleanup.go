@@ -0,0 +1,168 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"solo/pkg/models"
+	"solo/pkg/types/commontype"
+
+	"github.com/samber/lo"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// cleanupStep - 방 데이터 정리 단계, 여러 번 실행해도 결과가 같아야 함
+type cleanupStep struct {
+	name string
+	run  func(roomID string) error
+}
+
+// 보관이 켜져 있으면 삭제 전에 먼저 보관, 방 정보는 다른 단계에서 방을 찾을 수 있도록 마지막에 삭제
+func (s *ChatService) cleanupSteps() []cleanupStep {
+	var steps []cleanupStep
+	if roomArchiveEnabled() {
+		steps = append(steps, cleanupStep{name: "archive", run: s.ArchiveRoom})
+	}
+
+	return append(steps, []cleanupStep{
+		{name: "balance_form_votes", run: s.deleteBalanceFormChildren(s.chatRepo.DeleteBalanceFormVotes)},
+		{name: "balance_form_comments", run: s.deleteBalanceFormChildren(s.chatRepo.DeleteBalanceFormComments)},
+		{name: "balance_forms", run: s.chatRepo.DeleteBalanceFormsByRoomID},
+		{name: "message_readers", run: s.chatRepo.DeleteMessageReaders},
+		{name: "message_reactions", run: s.chatRepo.DeleteReactionsByRoomID},
+		{name: "chat_media", run: s.DeleteChatMediaByRoomID},
+		{name: "messages", run: s.chatRepo.DeleteChatByRoomID},
+		{name: "room", run: s.chatRepo.DeleteRoom},
+	}...)
+}
+
+// 이전 시도에서 완료한 단계를 제외한 나머지 단계 (순서 유지)
+func pendingCleanupSteps(steps []cleanupStep, done []string) []cleanupStep {
+	return lo.Filter(steps, func(step cleanupStep, _ int) bool { return !lo.Contains(done, step.name) })
+}
+
+// 방의 모든 밸런스 게임 폼에 대해 하위 데이터(투표, 댓글) 삭제
+func (s *ChatService) deleteBalanceFormChildren(deleteByForm func(formID primitive.ObjectID) error) func(roomID string) error {
+	return func(roomID string) error {
+		forms, err := s.chatRepo.GetBalanceFormsByRoomID(roomID)
+		if err != nil {
+			return err
+		}
+		for _, form := range forms {
+			if err := deleteByForm(form.ID); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// 방 데이터 정리 예약 (재시작해도 워커가 이어서 실행)
+func (s *ChatService) ScheduleRoomCleanup(roomID string, delay time.Duration) error {
+	runAt := time.Now().Add(delay)
+	err := s.chatRepo.ScheduleCleanupJob(roomID, runAt)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("🧹 Room %s cleanup scheduled at %s", roomID, runAt.Format(time.RFC3339))
+	return nil
+}
+
+// 예약된 방 정리 작업 실행 워커 (시작 시 밀린 작업부터 처리)
+func (s *ChatService) RunCleanupWorker() {
+	ticker := time.NewTicker(commontype.CleanupJobPollInterval)
+	defer ticker.Stop()
+
+	for {
+		s.runDueCleanupJobs()
+		<-ticker.C
+	}
+}
+
+func (s *ChatService) runDueCleanupJobs() {
+	for {
+		job, err := s.chatRepo.ClaimDueCleanupJob(time.Now(), commontype.CleanupJobLease)
+		if err != nil {
+			log.Printf("Failed to claim cleanup job: %v", err)
+			return
+		}
+		if job == nil {
+			return
+		}
+
+		s.runCleanupJob(job)
+	}
+}
+
+func (s *ChatService) runCleanupJob(job *models.CleanupJob) {
+	room, err := s.chatRepo.GetRoomByID(job.RoomID)
+	if err != nil {
+		s.failCleanupJob(job, fmt.Errorf("get room: %w", err))
+		return
+	}
+
+	// 방이 이미 지워진 경우(이전 시도에서 마지막 단계까지 실행)에도 남은 단계는 이어서 실행
+	if room != nil && room.Status != commontype.RoomStatusGameEnd {
+		log.Printf("⚠️ Room %s is not in GameEnd status, cleanup cancelled", job.RoomID)
+		if err := s.chatRepo.FinishCleanupJob(job.ID, commontype.CleanupJobStatusCancelled); err != nil {
+			log.Printf("Failed to cancel cleanup job for room %s: %v", job.RoomID, err)
+		}
+		return
+	}
+
+	// 보관 단계처럼 오래 걸리는 단계가 있어 실행 중에는 점유 시간을 계속 연장
+	stopRenew := s.keepCleanupJobLease(job)
+	defer stopRenew()
+
+	steps := s.cleanupSteps()
+	pending := pendingCleanupSteps(steps, job.Steps)
+	for i, step := range pending {
+		if err := step.run(job.RoomID); err != nil {
+			s.failCleanupJob(job, fmt.Errorf("%s: %w", step.name, err))
+			return
+		}
+
+		if err := s.chatRepo.MarkCleanupStepDone(job.ID, step.name); err != nil {
+			log.Printf("Failed to record cleanup step %s for room %s: %v", step.name, job.RoomID, err)
+		}
+		log.Printf("🧹 Room %s cleanup %d/%d: %s", job.RoomID, len(steps)-len(pending)+i+1, len(steps), step.name)
+	}
+
+	if err := s.chatRepo.FinishCleanupJob(job.ID, commontype.CleanupJobStatusDone); err != nil {
+		log.Printf("Failed to finish cleanup job for room %s: %v", job.RoomID, err)
+	}
+	log.Printf("✅ Successfully cleaned up all data for room %s", job.RoomID)
+}
+
+// keepCleanupJobLease - 작업이 끝날 때까지 주기적으로 점유 시간 연장, 반환된 함수로 중단
+func (s *ChatService) keepCleanupJobLease(job *models.CleanupJob) func() {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(commontype.CleanupJobLease / 3)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := s.chatRepo.RenewCleanupJobLease(job.ID, commontype.CleanupJobLease); err != nil {
+					log.Printf("Failed to renew cleanup job lease for room %s: %v", job.RoomID, err)
+				}
+			}
+		}
+	}()
+
+	return func() { close(done) }
+}
+
+func (s *ChatService) failCleanupJob(job *models.CleanupJob, cause error) {
+	retryAt := time.Now().Add(time.Duration(job.Attempts) * commontype.CleanupJobRetryDelay)
+	log.Printf("❌ Room %s cleanup failed (attempt %d/%d): %v", job.RoomID, job.Attempts, commontype.CleanupJobMaxAttempts, cause)
+
+	if err := s.chatRepo.FailCleanupJob(job, cause, retryAt); err != nil {
+		log.Printf("Failed to record cleanup failure for room %s: %v", job.RoomID, err)
+	}
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/samber/lo"
+)
+
+func cleanupStepNames(steps []cleanupStep) []string {
+	return lo.Map(steps, func(step cleanupStep, _ int) string { return step.name })
+}
+
+func TestCleanupStepsOrder(t *testing.T) {
+	t.Setenv("ROOM_ARCHIVE_ENABLED", "false")
+
+	names := cleanupStepNames((&ChatService{}).cleanupSteps())
+	if len(names) != len(lo.Uniq(names)) {
+		t.Fatalf("duplicate step names: %v", names)
+	}
+
+	// 다른 단계가 방/폼/메시지를 찾을 수 있도록 상위 데이터는 하위 데이터 뒤에 삭제
+	before := [][2]string{
+		{"balance_form_votes", "balance_forms"},
+		{"balance_form_comments", "balance_forms"},
+		{"message_readers", "messages"},
+		{"message_reactions", "messages"},
+		{"chat_media", "messages"},
+	}
+	for _, pair := range before {
+		if lo.IndexOf(names, pair[0]) > lo.IndexOf(names, pair[1]) {
+			t.Errorf("%s runs after %s: %v", pair[0], pair[1], names)
+		}
+	}
+	if names[len(names)-1] != "room" {
+		t.Errorf("last step = %s, want room", names[len(names)-1])
+	}
+}
+
+func TestPendingCleanupSteps(t *testing.T) {
+	steps := []cleanupStep{{name: "a"}, {name: "b"}, {name: "c"}, {name: "d"}}
+
+	// 재시도 시 이전에 완료한 단계는 건너뛰고 나머지는 원래 순서대로 실행
+	if got := cleanupStepNames(pendingCleanupSteps(steps, []string{"c", "a"})); len(got) != 2 || got[0] != "b" || got[1] != "d" {
+		t.Errorf("pending = %v, want [b d]", got)
+	}
+
+	if got := pendingCleanupSteps(steps, nil); len(got) != len(steps) {
+		t.Errorf("pending with nothing done = %v, want all steps", cleanupStepNames(got))
+	}
+	if got := pendingCleanupSteps(steps, []string{"a", "b", "c", "d"}); len(got) != 0 {
+		t.Errorf("pending with everything done = %v, want none", cleanupStepNames(got))
+	}
+}
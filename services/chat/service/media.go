@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"solo/pkg/config"
+	"solo/pkg/media"
+	"solo/pkg/models"
+	"solo/pkg/types/commontype"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var ErrMediaNotAllowed = errors.New("image messages are not allowed in this room now")
+
+// MaxMediaUploadBytes - 업로드 최대 크기
+func MaxMediaUploadBytes() int64 {
+	return int64(config.GetInt("MEDIA_MAX_UPLOAD_BYTES", commontype.MediaMaxUploadBytes))
+}
+
+// UploadChatMedia - 이미지 검증, 원본/썸네일 저장 후 미디어 정보 기록
+func (s *ChatService) UploadChatMedia(roomID string, userID int, reader io.Reader) (*models.ChatMedia, error) {
+	room, err := s.chatRepo.GetRoomByID(roomID)
+	if err != nil {
+		return nil, err
+	}
+	if room == nil {
+		return nil, errors.New("chat room not found")
+	}
+
+	// Redis 상태가 없으면 MongoDB 상태 사용
+	status, err := s.redisClient.GetRoomStatus(roomID)
+	if err != nil {
+		status = room.Status
+	}
+	if !media.ImageAllowedInRoom(room.Type, status) {
+		return nil, ErrMediaNotAllowed
+	}
+
+	// 최대 크기 + 1 바이트까지만 읽어서 초과 여부 판단
+	maxBytes := MaxMediaUploadBytes()
+	data, err := io.ReadAll(io.LimitReader(reader, maxBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read upload: %w", err)
+	}
+
+	image, err := media.ProcessImage(data, maxBytes, commontype.MediaThumbnailSize)
+	if err != nil {
+		return nil, err
+	}
+
+	mediaID := primitive.NewObjectID()
+	chatMedia := &models.ChatMedia{
+		ID:           mediaID,
+		RoomID:       roomID,
+		UploaderID:   userID,
+		ContentType:  image.ContentType,
+		Size:         int64(len(data)),
+		Width:        image.Width,
+		Height:       image.Height,
+		ObjectKey:    media.ObjectKey(roomID, mediaID.Hex(), image.Extension),
+		ThumbnailKey: media.ObjectKey(roomID, mediaID.Hex()+"_thumb", ".jpg"),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := s.mediaStore.Put(ctx, chatMedia.ObjectKey, image.ContentType, data); err != nil {
+		return nil, fmt.Errorf("failed to store media: %w", err)
+	}
+	if err := s.mediaStore.Put(ctx, chatMedia.ThumbnailKey, "image/jpeg", image.Thumbnail); err != nil {
+		s.deleteMediaObjects(chatMedia)
+		return nil, fmt.Errorf("failed to store thumbnail: %w", err)
+	}
+
+	if _, err := s.chatRepo.InsertChatMedia(chatMedia); err != nil {
+		s.deleteMediaObjects(chatMedia)
+		return nil, err
+	}
+
+	log.Printf("🖼️ User %d uploaded media %s to room %s (%d bytes)", userID, mediaID.Hex(), roomID, chatMedia.Size)
+	return chatMedia, nil
+}
+
+// OpenChatMedia - 방에 속한 미디어 원본 또는 썸네일 읽기
+func (s *ChatService) OpenChatMedia(ctx context.Context, roomID string, mediaID primitive.ObjectID, thumbnail bool) (io.ReadCloser, string, error) {
+	chatMedia, err := s.chatRepo.GetChatMediaByID(mediaID)
+	if err != nil {
+		return nil, "", err
+	}
+	if chatMedia == nil || chatMedia.RoomID != roomID {
+		return nil, "", media.ErrNotFound
+	}
+
+	key, contentType := chatMedia.ObjectKey, chatMedia.ContentType
+	if thumbnail {
+		key, contentType = chatMedia.ThumbnailKey, "image/jpeg"
+	}
+
+	reader, err := s.mediaStore.Open(ctx, key)
+	if err != nil {
+		return nil, "", err
+	}
+	return reader, contentType, nil
+}
+
+// DeleteChatMedia - 보내기 취소된 이미지의 미디어 객체와 기록 삭제
+// 객체 삭제에 실패하면 기록을 남겨 두어 방 정리 작업에서 다시 삭제되도록 함
+// 해당 메시지에 연결된 미디어만 삭제 (연결 기록이 없는 이전 미디어는 방 정리 때 삭제)
+func (s *ChatService) DeleteChatMedia(roomID string, mediaID, messageID primitive.ObjectID) error {
+	chatMedia, err := s.chatRepo.GetChatMediaByID(mediaID)
+	if err != nil {
+		return err
+	}
+	if chatMedia == nil || chatMedia.RoomID != roomID || chatMedia.MessageID != messageID {
+		return nil
+	}
+
+	if err := s.deleteMediaObjects(chatMedia); err != nil {
+		return err
+	}
+
+	return s.chatRepo.DeleteChatMediaByID(mediaID)
+}
+
+// DeleteChatMediaByRoomID - 방의 미디어 객체와 기록 삭제
+// 객체 삭제에 실패하면 기록을 남겨 두고 에러를 반환해 정리 작업이 재시도하도록 함
+func (s *ChatService) DeleteChatMediaByRoomID(roomID string) error {
+	mediaList, err := s.chatRepo.GetChatMediaByRoomID(roomID)
+	if err != nil {
+		return err
+	}
+
+	var firstErr error
+	for i := range mediaList {
+		if err := s.deleteMediaObjects(&mediaList[i]); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	if firstErr != nil {
+		return firstErr
+	}
+
+	return s.chatRepo.DeleteChatMediaByRoomID(roomID)
+}
+
+// deleteMediaObjects - 원본과 썸네일 삭제, 객체마다 제한 시간을 두고 첫 번째 에러 반환
+func (s *ChatService) deleteMediaObjects(chatMedia *models.ChatMedia) error {
+	var firstErr error
+	for _, key := range []string{chatMedia.ObjectKey, chatMedia.ThumbnailKey} {
+		ctx, cancel := context.WithTimeout(context.Background(), commontype.MediaDeleteTimeout)
+		err := s.mediaStore.Delete(ctx, key)
+		cancel()
+
+		if err != nil {
+			log.Printf("Failed to delete media object %s: %v", key, err)
+			if firstErr == nil {
+				firstErr = fmt.Errorf("failed to delete media object %s: %w", key, err)
+			}
+		}
+	}
+	return firstErr
+}
@@ -0,0 +1,214 @@
+package service
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"solo/pkg/dto"
+	"solo/pkg/models"
+	"solo/pkg/types/commontype"
+
+	"github.com/samber/lo"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 대화 내보내기 형식
+const (
+	TranscriptFormatJSON = "json"
+	TranscriptFormatText = "txt"
+)
+
+var ErrUnsupportedTranscriptFormat = errors.New("unsupported transcript format")
+
+// Transcript - 방 대화 내보내기 (방 정보와 밸런스 게임 질문을 미리 읽어두고 메시지는 스트리밍)
+type Transcript struct {
+	s      *ChatService
+	room   *models.ChatRoom
+	userID int
+	forms  map[primitive.ObjectID]models.BalanceGameForm
+}
+
+// 대화 내보내기 준비, 응답을 쓰기 전에 방과 밸런스 게임 정보를 확인
+func (s *ChatService) NewTranscript(roomID string, userID int) (*Transcript, error) {
+	room, err := s.chatRepo.GetRoomByID(roomID)
+	if err != nil {
+		return nil, err
+	}
+	if room == nil {
+		return nil, errors.New("chat room not found")
+	}
+
+	forms, err := s.chatRepo.GetBalanceFormsByRoomID(roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Transcript{
+		s:      s,
+		room:   room,
+		userID: userID,
+		forms:  lo.KeyBy(forms, func(f models.BalanceGameForm) primitive.ObjectID { return f.ID }),
+	}, nil
+}
+
+// 파일 이름 (확장자 포함)
+func (t *Transcript) FileName(format string) string {
+	return fmt.Sprintf("chat-%d-%s.%s", t.room.Seq, t.room.CreatedAt.Format("20060102"), format)
+}
+
+// 지정한 형식으로 대화 내용을 w에 스트리밍
+func (t *Transcript) Write(format string, w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	var err error
+	switch format {
+	case TranscriptFormatJSON:
+		err = t.writeJSON(bw)
+	case TranscriptFormatText:
+		err = t.writeText(bw)
+	default:
+		return ErrUnsupportedTranscriptFormat
+	}
+	if err != nil {
+		return err
+	}
+
+	return bw.Flush()
+}
+
+func (t *Transcript) writeJSON(w *bufio.Writer) error {
+	header, err := json.Marshal(map[string]interface{}{
+		"room_id":     t.room.ID,
+		"room_name":   t.room.Name,
+		"exported_at": time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+
+	// 헤더 객체의 닫는 괄호를 빼고 messages 배열을 이어서 씀
+	w.Write(header[:len(header)-1])
+	w.WriteString(`,"messages":[`)
+
+	first := true
+	err = t.s.chatRepo.StreamVisibleMessages(t.room.ID, t.userID, func(chat *models.Chat) error {
+		data, err := json.Marshal(t.toTranscriptMessage(chat))
+		if err != nil {
+			return err
+		}
+		if !first {
+			w.WriteByte(',')
+		}
+		first = false
+		_, err = w.Write(data)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = w.WriteString("]}\n")
+	return err
+}
+
+func (t *Transcript) writeText(w *bufio.Writer) error {
+	fmt.Fprintf(w, "%s\n", t.room.Name)
+	fmt.Fprintf(w, "내보낸 시각: %s\n\n", time.Now().Format("2006-01-02 15:04"))
+
+	return t.s.chatRepo.StreamVisibleMessages(t.room.ID, t.userID, func(chat *models.Chat) error {
+		_, err := w.WriteString(t.formatTextLine(t.toTranscriptMessage(chat)))
+		return err
+	})
+}
+
+func (t *Transcript) toTranscriptMessage(chat *models.Chat) dto.TranscriptMessage {
+	message := dto.TranscriptMessage{
+		MessageID: chat.MessageId.Hex(),
+		Type:      chat.Type,
+		Sender:    t.characterName(chat.SenderID),
+		Message:   chat.Message,
+		CreatedAt: chat.CreatedAt,
+		EditedAt:  chat.EditedAt,
+		Deleted:   chat.DeletedAt != nil,
+	}
+	if chat.Media != nil {
+		message.ImageURL = chat.Media.URL
+	}
+	if !chat.ReplyTo.IsZero() {
+		message.ReplyTo = chat.ReplyTo.Hex()
+	}
+
+	if form, ok := t.forms[chat.BalanceFormID]; ok && !chat.BalanceFormID.IsZero() {
+		balance := &dto.TranscriptBalance{
+			Title: form.Question.Title,
+			Red:   form.Question.Red,
+			Blue:  form.Question.Blue,
+		}
+		if chat.Type == commontype.ChatTypeFormResult && chat.BalanceResult != nil {
+			balance.Votes = &chat.BalanceResult.Votes
+			balance.WinnerTeam = &chat.BalanceResult.WinnerTeam
+			balance.Sides = lo.Map(chat.BalanceResult.Sides, func(side models.BalanceFormSide, _ int) dto.TranscriptBalanceSide {
+				return dto.TranscriptBalanceSide{CharacterName: side.CharacterName, Choiced: side.Choiced}
+			})
+		}
+		message.Balance = balance
+	}
+
+	return message
+}
+
+func (t *Transcript) formatTextLine(message dto.TranscriptMessage) string {
+	var line strings.Builder
+
+	sender := lo.Ternary(message.Sender != "", message.Sender, "시스템")
+	fmt.Fprintf(&line, "[%s] %s: ", message.CreatedAt.Format("2006-01-02 15:04"), sender)
+
+	switch {
+	case message.Deleted:
+		line.WriteString("(보내기 취소된 메시지)")
+	case message.ImageURL != "":
+		fmt.Fprintf(&line, "[이미지] %s", message.ImageURL)
+	default:
+		line.WriteString(message.Message)
+		if message.EditedAt != nil {
+			line.WriteString(" (수정됨)")
+		}
+	}
+	line.WriteString("\n")
+
+	if balance := message.Balance; balance != nil {
+		fmt.Fprintf(&line, "    밸런스 게임: %s (🔴 %s / 🔵 %s)\n", balance.Title, balance.Red, balance.Blue)
+		if balance.Votes != nil {
+			fmt.Fprintf(&line, "    결과: 🔴 %d표 / 🔵 %d표\n", balance.Votes.RedCount, balance.Votes.BlueCount)
+			for _, side := range balance.Sides {
+				choice := "미투표"
+				switch side.Choiced {
+				case commontype.BalanceFormVoteRed:
+					choice = balance.Red
+				case commontype.BalanceFormVoteBlue:
+					choice = balance.Blue
+				}
+				fmt.Fprintf(&line, "    - %s: %s\n", side.CharacterName, choice)
+			}
+		}
+	}
+
+	return line.String()
+}
+
+// 발신자 캐릭터 이름, 시스템 메시지(발신자 0)는 빈 문자열
+func (t *Transcript) characterName(userID int) string {
+	if userID == 0 {
+		return ""
+	}
+	gamer, ok := lo.Find(t.room.Gamers, func(g models.GamerInfo) bool { return g.UserID == userID })
+	if !ok {
+		return "알 수 없음"
+	}
+	return gamer.CharacterName
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"solo/pkg/models"
+	"solo/pkg/types/commontype"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func newTestTranscript(forms ...models.BalanceGameForm) *Transcript {
+	t := &Transcript{
+		room: &models.ChatRoom{
+			ID:        "room",
+			Seq:       42,
+			CreatedAt: time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC),
+			Gamers:    []models.GamerInfo{{UserID: 1, CharacterName: "호랑이"}, {UserID: 2, CharacterName: "토끼"}},
+		},
+		forms: map[primitive.ObjectID]models.BalanceGameForm{},
+	}
+	for _, form := range forms {
+		t.forms[form.ID] = form
+	}
+	return t
+}
+
+func TestTranscriptTextLines(t *testing.T) {
+	transcript := newTestTranscript()
+	at := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	deletedAt := at.Add(time.Minute)
+
+	lines := []struct {
+		chat *models.Chat
+		want string
+	}{
+		{&models.Chat{SenderID: 1, Message: "안녕하세요", CreatedAt: at}, "[2026-03-01 09:30] 호랑이: 안녕하세요\n"},
+		{&models.Chat{SenderID: 2, Message: "반가워요", CreatedAt: at, EditedAt: &deletedAt}, "[2026-03-01 09:30] 토끼: 반가워요 (수정됨)\n"},
+		{&models.Chat{SenderID: 1, CreatedAt: at, DeletedAt: &deletedAt}, "[2026-03-01 09:30] 호랑이: (보내기 취소된 메시지)\n"},
+		{&models.Chat{SenderID: 2, CreatedAt: at, Media: &models.MediaRef{URL: "/media/a.jpg"}}, "[2026-03-01 09:30] 토끼: [이미지] /media/a.jpg\n"},
+		{&models.Chat{SenderID: 0, Message: "대화가 시작되었습니다.", CreatedAt: at}, "[2026-03-01 09:30] 시스템: 대화가 시작되었습니다.\n"},
+		{&models.Chat{SenderID: 9, Message: "누구?", CreatedAt: at}, "[2026-03-01 09:30] 알 수 없음: 누구?\n"},
+	}
+
+	for _, line := range lines {
+		if got := transcript.formatTextLine(transcript.toTranscriptMessage(line.chat)); got != line.want {
+			t.Errorf("formatTextLine() = %q, want %q", got, line.want)
+		}
+	}
+}
+
+func TestTranscriptBalanceResult(t *testing.T) {
+	form := models.BalanceGameForm{
+		ID:       primitive.NewObjectID(),
+		Question: models.Question{Title: "여행은?", Red: "산", Blue: "바다"},
+	}
+	transcript := newTestTranscript(form)
+
+	chat := &models.Chat{
+		Type:          commontype.ChatTypeFormResult,
+		BalanceFormID: form.ID,
+		Message:       "밸런스 게임 결과",
+		CreatedAt:     time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC),
+		BalanceResult: &models.BalanceFormResult{
+			Votes:      models.Votes{RedCount: 1, BlueCount: 0},
+			WinnerTeam: commontype.BalanceGameWinnerRed,
+			Sides: []models.BalanceFormSide{
+				{UserID: 1, CharacterName: "호랑이", Choiced: commontype.BalanceFormVoteRed},
+				{UserID: 2, CharacterName: "토끼", Choiced: commontype.BalanceFormVoteNone},
+			},
+		},
+	}
+
+	got := transcript.formatTextLine(transcript.toTranscriptMessage(chat))
+	want := strings.Join([]string{
+		"[2026-03-01 10:00] 시스템: 밸런스 게임 결과",
+		"    밸런스 게임: 여행은? (🔴 산 / 🔵 바다)",
+		"    결과: 🔴 1표 / 🔵 0표",
+		"    - 호랑이: 산",
+		"    - 토끼: 미투표",
+		"",
+	}, "\n")
+	if got != want {
+		t.Errorf("formatTextLine() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestTranscriptFileNameAndFormat(t *testing.T) {
+	transcript := newTestTranscript()
+	if got := transcript.FileName(TranscriptFormatText); got != "chat-42-20260301.txt" {
+		t.Errorf("FileName() = %s, want chat-42-20260301.txt", got)
+	}
+
+	var out strings.Builder
+	if err := transcript.Write("pdf", &out); err != ErrUnsupportedTranscriptFormat {
+		t.Errorf("Write(pdf) error = %v, want ErrUnsupportedTranscriptFormat", err)
+	}
+}
@@ -1,6 +1,7 @@
 package transport
 
 import (
+	"solo/pkg/middleware"
 	"solo/services/chat/handler"
 	"solo/services/chat/service"
 
@@ -27,5 +28,21 @@ func NewRouter(chatHandler *handler.ChatHandler, chatService *service.ChatServic
 	e.DELETE("/all/:id", chatHandler.DeleteChatByRoomID)
 	e.GET("/character/name/:id", chatHandler.GetCharacterNameByRoomID)
 
+	// 채팅 미디어 (방 참가자만 접근)
+	roomAccess := middleware.RoomAccessChecker(chatService)
+	e.POST("/media/:id", chatHandler.UploadChatMedia, roomAccess)
+	e.GET("/media/:id/:mediaid", chatHandler.GetChatMedia, roomAccess)
+
+	// 채팅방 메시지 검색 (방 참가자만 접근)
+	e.GET("/search/:id", chatHandler.SearchChatMessages, roomAccess)
+
+	// 채팅방 대화 내보내기 (방 참가자만 접근)
+	e.GET("/export/:id", chatHandler.ExportChatTranscript, roomAccess)
+
+	// 보관된 방 데이터 조회 (관리자 전용)
+	adminOnly := middleware.AdminOnly()
+	e.GET("/admin/archive/:id", chatHandler.GetRoomArchive, adminOnly)
+	e.GET("/admin/archive/seq/:seq", chatHandler.GetRoomArchive, adminOnly)
+
 	return e
 }
@@ -5,17 +5,24 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
+	"solo/pkg/config"
 	"solo/pkg/db"
 	"solo/pkg/logger"
 	"solo/pkg/mq"
 	"solo/pkg/redis"
+	"solo/pkg/types/commontype"
 	"solo/services/chat/repo"
 	"solo/services/game/event"
 	"solo/services/game/handler"
+	"solo/services/game/moderation"
 	"solo/services/game/service"
 	"solo/services/game/transport"
+	"solo/services/user/repository"
 )
 
 const webPort = 80
@@ -34,6 +41,16 @@ func main() {
 	}
 	defer mongoClient.Disconnect(ctx)
 
+	// MySQL 연결 (게임 포인트) - 방 연장에 포인트를 차감할 때만 필요
+	var userRepo *repository.UserRepository
+	if config.GetInt("ROOM_EXTEND_POINT_COST", commontype.RoomExtendPointCost) > 0 {
+		mysqlClient, err := db.ConnectMySQL()
+		if err != nil {
+			log.Panic("MySQL 연결 실패: ", err)
+		}
+		userRepo = repository.NewUserRepository(mysqlClient)
+	}
+
 	// RabbitMQ 연결
 	mqClient, err := mq.ConnectToRabbitMQ()
 	if err != nil {
@@ -56,7 +73,7 @@ func main() {
 	if err != nil {
 		log.Panic("ChatRepository 생성 실패: ", err)
 	}
-	gameService := service.NewGameService(redisClient, emitter, chatRepo)
+	gameService := service.NewGameService(redisClient, emitter, chatRepo, userRepo, moderation.NewDefaultPipeline())
 
 	// WebSocket 핸들러
 	gameHandler := handler.NewGameHandler(gameService)
@@ -71,9 +88,29 @@ func main() {
 		Handler: transport.NewRouter(gameHandler, redisClient),
 	}
 
-	log.Printf("🚀 Game Service Started on Port %d", webPort)
-	err = srv.ListenAndServe()
+	go func() {
+		log.Printf("🚀 Game Service Started on Port %d", webPort)
+		err := srv.ListenAndServe()
+		if err != nil && err != http.ErrServerClosed {
+			log.Fatal(err)
+		}
+	}()
+
+	// 종료 시그널 대기 (배포 시 SIGTERM)
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	// 종료 제한 시간 내에 클라이언트 정리 후 서버 종료
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), config.GetDuration("GAME_SHUTDOWN_TIMEOUT", 20*time.Second))
+	defer shutdownCancel()
+
+	gameService.Drain(shutdownCtx, config.GetDuration("GAME_RECONNECT_HINT", 2*time.Second))
+
+	err = srv.Shutdown(shutdownCtx)
 	if err != nil {
-		log.Fatal(err)
+		log.Printf("❌ Game Service shutdown 실패: %v", err)
 	}
+
+	log.Println("👋 Game Service Stopped")
 }
@@ -40,6 +40,8 @@ func (c *Consumer) StartListening() {
 			mq.RoutingKeyRoomLeave,
 			mq.RoutingKeyRoomTimeout,
 			mq.RoutingKeyVoteCommentChat,
+			mq.RoutingKeyRoomRemainTime,
+			mq.RoutingKeyCoupleRoomClose,
 		})
 	if err != nil {
 		log.Fatalf("❌ Failed to declare queue %s for %s: %v", mq.QueueGame, mq.ExchangeAppTopic, err)
@@ -59,10 +61,12 @@ func (c *Consumer) StartListening() {
 		eventtypes.EventTypeRoomTimeout:        c.eventHandler.HandleRoomTimeoutEvent,
 		eventtypes.EventTypeFinalChoiceTimeout: c.eventHandler.HandleFinalChoiceTimeoutEvent,
 		eventtypes.EventTypeVoteCommentChat:    c.eventHandler.HandleVoteCommentChatEvent,
+		eventtypes.EventTypeRoomRemainTime:     c.eventHandler.HandleRoomRemainTimeEvent,
+		eventtypes.EventTypeCoupleRoomClose:    c.eventHandler.HandleCoupleRoomCloseEvent,
 	}
 
-	// 메시지 소비 시작
-	c.mqClient.ConsumeMessages(queue.Name, handlers)
+	// 메시지 소비 시작 (같은 방의 이벤트는 순서대로 처리)
+	c.mqClient.ConsumeMessagesWithMode(queue.Name, handlers, mq.DispatchPerRoom)
 
 	log.Println("✅ RabbitMQ Consumer Listening...")
 }
@@ -37,7 +37,13 @@ func (e *EventHandler) HandleChatEvent(payload json.RawMessage) {
 		Payload: payload,
 	}
 
-	err := e.gameService.SendMessageToRoom(chatEvent.RoomID, wsMessage)
+	// 공개 범위가 지정된 메시지(귓속말)는 해당 사용자에게만 전송
+	var err error
+	if len(chatEvent.VisibleTo) > 0 {
+		err = e.gameService.SendMessageToUsers(chatEvent.VisibleTo, wsMessage)
+	} else {
+		err = e.gameService.SendMessageToRoom(chatEvent.RoomID, wsMessage)
+	}
 	if err != nil {
 		printer.PrintError("Failed to send message via WebSocket", err)
 	}
@@ -180,3 +186,50 @@ func (e *EventHandler) HandleVoteCommentChatEvent(payload json.RawMessage) {
 		printer.PrintError("Failed to send vote comment chat message via WebSocket", err)
 	}
 }
+
+func (e *EventHandler) HandleRoomRemainTimeEvent(payload json.RawMessage) {
+	var roomRemainTime eventtypes.RoomRemainTimeEvent
+	if err := json.Unmarshal(payload, &roomRemainTime); err != nil {
+		printer.PrintError("Failed to unmarshal room remain time event", err)
+		return
+	}
+
+	printer.PrintSuccess(fmt.Sprintf("Broadcasting room remaining time, Room ID: %s, Remaining: %ds", roomRemainTime.RoomID, roomRemainTime.RemainingSeconds))
+
+	wsMessage := stype.WebSocketMessage{
+		Kind: stype.MessageKindRoomRemaining,
+		Payload: helper.ToJSON(stype.RoomRemainingMessage{
+			RoomID:           roomRemainTime.RoomID,
+			Phase:            roomRemainTime.Phase,
+			RemainingSeconds: roomRemainTime.RemainingSeconds,
+		}),
+	}
+
+	err := e.gameService.SendMessageToRoom(roomRemainTime.RoomID, wsMessage)
+	if err != nil {
+		printer.PrintError("Failed to send room remaining message via WebSocket", err)
+	}
+}
+
+func (e *EventHandler) HandleCoupleRoomCloseEvent(payload json.RawMessage) {
+	var closeEvent eventtypes.CoupleRoomCloseEvent
+	if err := json.Unmarshal(payload, &closeEvent); err != nil {
+		printer.PrintError("Failed to unmarshal couple room close event", err)
+		return
+	}
+
+	printer.PrintSuccess("Broadcasting couple room close for Room " + closeEvent.RoomID)
+
+	wsMessage := stype.WebSocketMessage{
+		Kind: stype.MessageKindCoupleRoomClosed,
+		Payload: helper.ToJSON(stype.CoupleRoomClosedMessage{
+			RoomID:   closeEvent.RoomID,
+			ClosedAt: closeEvent.ClosedAt,
+		}),
+	}
+
+	err := e.gameService.SendMessageToRoom(closeEvent.RoomID, wsMessage)
+	if err != nil {
+		printer.PrintError("Failed to send couple room closed message via WebSocket", err)
+	}
+}
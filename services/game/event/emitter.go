@@ -76,6 +76,14 @@ func (e *Emitter) PublishFinalChoiceTimeoutEvent(event eventtypes.FinalChoiceTim
 	return nil
 }
 
+func (e *Emitter) PublishRoomRemainTimeEvent(event eventtypes.RoomRemainTimeEvent) error {
+	payload := eventtypes.EventPayload{
+		EventType: eventtypes.EventTypeRoomRemainTime,
+		Data:      helper.ToJSON(event),
+	}
+	return e.publish(mq.ExchangeAppTopic, mq.RoutingKeyRoomRemainTime, payload)
+}
+
 func (e *Emitter) PublishRoomTimeoutEvent(timeoutEvent eventtypes.RoomTimeoutEvent) error {
 	payload := eventtypes.EventPayload{
 		EventType: eventtypes.EventTypeRoomTimeout,
@@ -83,3 +91,19 @@ func (e *Emitter) PublishRoomTimeoutEvent(timeoutEvent eventtypes.RoomTimeoutEve
 	}
 	return e.publish(mq.ExchangeAppTopic, mq.RoutingKeyRoomTimeout, payload)
 }
+
+func (e *Emitter) PublishCoupleRoomCloseEvent(event eventtypes.CoupleRoomCloseEvent) error {
+	payload := eventtypes.EventPayload{
+		EventType: eventtypes.EventTypeCoupleRoomClose,
+		Data:      helper.ToJSON(event),
+	}
+	return e.publish(mq.ExchangeAppTopic, mq.RoutingKeyCoupleRoomClose, payload)
+}
+
+func (e *Emitter) PublishChatMediaDeleteEvent(event eventtypes.ChatMediaDeleteEvent) error {
+	payload := eventtypes.EventPayload{
+		EventType: eventtypes.EventTypeChatMediaDelete,
+		Data:      helper.ToJSON(event),
+	}
+	return e.publish(mq.ExchangeAppTopic, mq.RoutingKeyChatMediaDelete, payload)
+}
@@ -36,6 +36,11 @@ var upgrader = websocket.Upgrader{
 }
 
 func (h *GameHandler) HandleGameSocket(c echo.Context) error {
+	// 종료 준비 중에는 새 연결을 받지 않음
+	if h.gameService.IsDraining() {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "Server is restarting")
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
@@ -137,6 +142,8 @@ func (h *GameHandler) listenForMessages(ctx context.Context, cancel context.Canc
 				}
 			case stype.MessageKindMessage:
 				h.handleMessage(wsMsg.Payload, userID)
+			case stype.MessageKindResume:
+				h.handleResume(wsMsg.Payload, userID)
 			case stype.MessageKindJoin:
 				h.handleJoinMessage(wsMsg.Payload, userID)
 			case stype.MessageKindLeave:
@@ -145,6 +152,28 @@ func (h *GameHandler) listenForMessages(ctx context.Context, cancel context.Canc
 				h.handleRoomTimeout(wsMsg.Payload, userID)
 			case stype.MessageKindFinalChoice:
 				h.handleFinalChoice(wsMsg.Payload, userID)
+			case stype.MessageKindRoomRemaining:
+				h.handleRoomRemaining(wsMsg.Payload, userID)
+			case stype.MessageKindWhisper:
+				h.handleWhisper(wsMsg.Payload, userID)
+			case stype.MessageKindImage:
+				h.handleImage(wsMsg.Payload, userID)
+			case stype.MessageKindMessageEdit:
+				h.handleMessageEdit(wsMsg.Payload, userID)
+			case stype.MessageKindMessageDelete:
+				h.handleMessageDelete(wsMsg.Payload, userID)
+			case stype.MessageKindReaction:
+				h.handleReaction(wsMsg.Payload, userID)
+			case stype.MessageKindContactExchange:
+				h.handleContactExchange(wsMsg.Payload, userID)
+			case stype.MessageKindCoupleExtend:
+				h.handleCoupleExtend(wsMsg.Payload, userID)
+			case stype.MessageKindRoomExtendPropose:
+				h.handleRoomExtendPropose(wsMsg.Payload, userID)
+			case stype.MessageKindRoomExtendVote:
+				h.handleRoomExtendVote(wsMsg.Payload, userID)
+			case stype.MessageKindActivityInput:
+				h.handleActivityInput(wsMsg.Payload, userID)
 			default:
 				log.Printf("❌ 알 수 없는 메시지 타입: %s", wsMsg.Kind)
 			}
@@ -160,7 +189,7 @@ func (h *GameHandler) handleMessage(payload json.RawMessage, userID int) {
 	}
 
 	// GameService를 통해 메시지 브로드캐스트
-	err := h.gameService.BroadcastMessage(chatMsg.RoomID, userID, chatMsg.Message, chatMsg.HeadCnt)
+	err := h.gameService.BroadcastMessage(chatMsg.RoomID, userID, chatMsg.Message, chatMsg.HeadCnt, chatMsg.ReplyTo)
 	if err != nil {
 		log.Printf("❌ BroadcastMessage 실패: %v", err)
 		return
@@ -187,6 +216,20 @@ func (h *GameHandler) handleJoinMessage(payload json.RawMessage, userID int) {
 	log.Printf("🎮 User %d joined room %s", userID, joinMsg.RoomID)
 }
 
+// handleResume - 앱 복귀 후 세션 복구 처리
+func (h *GameHandler) handleResume(payload json.RawMessage, userID int) {
+	var resumeMsg stype.ResumeMessage
+	if err := json.Unmarshal(payload, &resumeMsg); err != nil {
+		log.Printf("❌ Resume 메시지 파싱 실패: %v", err)
+		return
+	}
+
+	err := h.gameService.Resume(userID, resumeMsg)
+	if err != nil {
+		log.Printf("❌ Resume 처리 실패: %v", err)
+	}
+}
+
 // handleLeaveMessage - 게임 나가기 처리
 func (h *GameHandler) handleLeaveMessage(payload json.RawMessage, userID int) {
 	var leaveMsg stype.LeaveRoomMessage
@@ -232,6 +275,160 @@ func (h *GameHandler) handleFinalChoice(payload json.RawMessage, userID int) {
 	}
 }
 
+// handleRoomRemaining - 남은 시간 조회 요청 처리
+func (h *GameHandler) handleRoomRemaining(payload json.RawMessage, userID int) {
+	var roomRemainingMsg stype.RoomRemainingMessage
+	if err := json.Unmarshal(payload, &roomRemainingMsg); err != nil {
+		log.Printf("❌ Room Remaining 메시지 파싱 실패: %v", err)
+		return
+	}
+
+	err := h.gameService.SendRoomRemaining(userID, roomRemainingMsg.RoomID)
+	if err != nil {
+		log.Printf("❌ Room Remaining 처리 실패: %v", err)
+	}
+}
+
+// handleWhisper - 귓속말 처리
+func (h *GameHandler) handleWhisper(payload json.RawMessage, userID int) {
+	var whisperMsg stype.WhisperMessage
+	if err := json.Unmarshal(payload, &whisperMsg); err != nil {
+		log.Printf("❌ Whisper 메시지 파싱 실패: %v", err)
+		return
+	}
+
+	err := h.gameService.SendWhisper(userID, whisperMsg)
+	if err != nil {
+		log.Printf("❌ Whisper 처리 실패: %v", err)
+	}
+}
+
+// handleImage - 이미지 메시지 전송 처리
+func (h *GameHandler) handleImage(payload json.RawMessage, userID int) {
+	var imageMsg stype.ImageMessage
+	if err := json.Unmarshal(payload, &imageMsg); err != nil {
+		log.Printf("❌ Image 메시지 파싱 실패: %v", err)
+		return
+	}
+
+	err := h.gameService.SendImage(userID, imageMsg)
+	if err != nil {
+		log.Printf("❌ Image 전송 실패: %v", err)
+	}
+}
+
+// handleMessageEdit - 메시지 수정 처리
+func (h *GameHandler) handleMessageEdit(payload json.RawMessage, userID int) {
+	var editMsg stype.MessageEditMessage
+	if err := json.Unmarshal(payload, &editMsg); err != nil {
+		log.Printf("❌ MessageEdit 메시지 파싱 실패: %v", err)
+		return
+	}
+
+	err := h.gameService.EditMessage(userID, editMsg)
+	if err != nil {
+		log.Printf("❌ MessageEdit 처리 실패: %v", err)
+	}
+}
+
+// handleMessageDelete - 메시지 보내기 취소 처리
+func (h *GameHandler) handleMessageDelete(payload json.RawMessage, userID int) {
+	var deleteMsg stype.MessageDeleteMessage
+	if err := json.Unmarshal(payload, &deleteMsg); err != nil {
+		log.Printf("❌ MessageDelete 메시지 파싱 실패: %v", err)
+		return
+	}
+
+	err := h.gameService.DeleteMessage(userID, deleteMsg)
+	if err != nil {
+		log.Printf("❌ MessageDelete 처리 실패: %v", err)
+	}
+}
+
+// handleReaction - 메시지 리액션 추가/제거 처리
+func (h *GameHandler) handleReaction(payload json.RawMessage, userID int) {
+	var reactionMsg stype.ReactionMessage
+	if err := json.Unmarshal(payload, &reactionMsg); err != nil {
+		log.Printf("❌ Reaction 메시지 파싱 실패: %v", err)
+		return
+	}
+
+	err := h.gameService.HandleReaction(userID, reactionMsg)
+	if err != nil {
+		log.Printf("❌ Reaction 처리 실패: %v", err)
+	}
+}
+
+// handleCoupleExtend - 커플 채팅방 연장 동의/철회 처리
+func (h *GameHandler) handleCoupleExtend(payload json.RawMessage, userID int) {
+	var extendMsg stype.CoupleExtendMessage
+	if err := json.Unmarshal(payload, &extendMsg); err != nil {
+		log.Printf("❌ CoupleExtend 메시지 파싱 실패: %v", err)
+		return
+	}
+
+	err := h.gameService.ExtendCoupleRoom(userID, extendMsg)
+	if err != nil {
+		log.Printf("❌ CoupleExtend 처리 실패: %v", err)
+	}
+}
+
+// handleContactExchange - 커플 채팅방 연락처 제출/확인/취소 처리
+func (h *GameHandler) handleContactExchange(payload json.RawMessage, userID int) {
+	var contactMsg stype.ContactExchangeMessage
+	if err := json.Unmarshal(payload, &contactMsg); err != nil {
+		log.Printf("❌ ContactExchange 메시지 파싱 실패: %v", err)
+		return
+	}
+
+	err := h.gameService.ExchangeContact(userID, contactMsg)
+	if err != nil {
+		log.Printf("❌ ContactExchange 처리 실패: %v", err)
+	}
+}
+
+// handleRoomExtendPropose - 대화 시간 연장 제안 처리
+func (h *GameHandler) handleRoomExtendPropose(payload json.RawMessage, userID int) {
+	var proposeMsg stype.RoomExtendProposeMessage
+	if err := json.Unmarshal(payload, &proposeMsg); err != nil {
+		log.Printf("❌ Room Extend Propose 메시지 파싱 실패: %v", err)
+		return
+	}
+
+	err := h.gameService.ProposeRoomExtension(userID, proposeMsg)
+	if err != nil {
+		log.Printf("❌ Room Extend Propose 처리 실패: %v", err)
+	}
+}
+
+// handleRoomExtendVote - 대화 시간 연장 투표 처리
+func (h *GameHandler) handleRoomExtendVote(payload json.RawMessage, userID int) {
+	var voteMsg stype.RoomExtendVoteMessage
+	if err := json.Unmarshal(payload, &voteMsg); err != nil {
+		log.Printf("❌ Room Extend Vote 메시지 파싱 실패: %v", err)
+		return
+	}
+
+	err := h.gameService.VoteRoomExtension(userID, voteMsg)
+	if err != nil {
+		log.Printf("❌ Room Extend Vote 처리 실패: %v", err)
+	}
+}
+
+// handleActivityInput - 방 활동(밸런스 게임 등) 입력 처리
+func (h *GameHandler) handleActivityInput(payload json.RawMessage, userID int) {
+	var activityInputMsg stype.ActivityInputMessage
+	if err := json.Unmarshal(payload, &activityInputMsg); err != nil {
+		log.Printf("❌ Activity Input 메시지 파싱 실패: %v", err)
+		return
+	}
+
+	err := h.gameService.HandleActivityInput(userID, activityInputMsg)
+	if err != nil {
+		log.Printf("❌ Activity Input 처리 실패: %v", err)
+	}
+}
+
 // pingPongHandler - 클라이언트 연결 상태 유지
 func (h *GameHandler) pingPongHandler(ctx context.Context, cancel context.CancelFunc, conn *websocket.Conn, userID int, pongChannel chan bool) {
 	ticker := time.NewTicker(5 * time.Second)
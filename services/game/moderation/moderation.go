@@ -0,0 +1,94 @@
+package moderation
+
+import (
+	"solo/pkg/config"
+	"solo/pkg/types/commontype"
+)
+
+// Action은 검열 단계의 처리 결과
+type Action string
+
+const (
+	ActionAllow  Action = "allow"
+	ActionMask   Action = "mask"
+	ActionReject Action = "reject"
+)
+
+// Input은 검열 대상 메시지와 방 정보
+type Input struct {
+	RoomID     string
+	RoomType   int
+	RoomStatus int
+	SenderID   int
+	Message    string
+}
+
+// Verdict는 단일 단계의 판정 결과
+type Verdict struct {
+	Action  Action
+	Message string // Allow/Mask 시 다음 단계로 넘길 메시지
+	Reason  string
+}
+
+// Stage는 파이프라인에 끼워 넣을 수 있는 검열 단계
+type Stage interface {
+	Name() string
+	Check(input Input) Verdict
+}
+
+// Result는 파이프라인 전체 실행 결과
+type Result struct {
+	Action  Action `json:"action"`
+	Message string `json:"message"`
+	Stage   string `json:"stage,omitempty"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+// Pipeline은 등록된 단계를 순서대로 실행
+type Pipeline struct {
+	stages []Stage
+}
+
+func NewPipeline(stages ...Stage) *Pipeline {
+	return &Pipeline{stages: stages}
+}
+
+// NewDefaultPipeline: 종료된 방 → 길이/공백 → 금칙어 → 연락처 순서의 기본 파이프라인 생성
+func NewDefaultPipeline() *Pipeline {
+	return NewPipeline(
+		NewClosedRoomStage(),
+		NewLengthStage(config.GetInt("CHAT_MAX_MESSAGE_LENGTH", commontype.DEFAULT_MAX_MESSAGE_LENGTH)),
+		NewBannedWordStage(config.GetStringList("CHAT_BANNED_WORDS", defaultBannedWords)),
+		NewContactStage(),
+	)
+}
+
+// Run: 단계별로 메시지를 검사하며, Reject가 나오면 즉시 중단
+func (p *Pipeline) Run(input Input) Result {
+	result := Result{Action: ActionAllow, Message: input.Message}
+
+	for _, stage := range p.stages {
+		verdict := stage.Check(input)
+
+		switch verdict.Action {
+		case ActionReject:
+			return Result{
+				Action: ActionReject,
+				Stage:  stage.Name(),
+				Reason: verdict.Reason,
+			}
+		case ActionMask:
+			// 여러 단계에서 마스킹되면 첫 사유를 유지
+			if result.Action != ActionMask {
+				result.Stage = stage.Name()
+				result.Reason = verdict.Reason
+			}
+			result.Action = ActionMask
+		}
+
+		input.Message = verdict.Message
+		result.Message = verdict.Message
+	}
+
+	return result
+}
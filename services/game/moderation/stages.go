@@ -0,0 +1,173 @@
+package moderation
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"solo/pkg/types/commontype"
+)
+
+var defaultBannedWords = []string{
+	"시발",
+	"씨발",
+	"병신",
+	"개새끼",
+	"좆",
+}
+
+// ClosedRoomStage - 종료된 커플 채팅방에는 메시지 전송 불가
+type ClosedRoomStage struct{}
+
+func NewClosedRoomStage() *ClosedRoomStage {
+	return &ClosedRoomStage{}
+}
+
+func (s *ClosedRoomStage) Name() string {
+	return "closed_room"
+}
+
+func (s *ClosedRoomStage) Check(input Input) Verdict {
+	if input.RoomType == commontype.MATCH_COUPLE && input.RoomStatus >= commontype.RoomStatusGameEnd {
+		return Verdict{Action: ActionReject, Reason: "종료된 대화방에는 메시지를 보낼 수 없습니다"}
+	}
+	return Verdict{Action: ActionAllow, Message: input.Message}
+}
+
+// LengthStage - 빈 메시지, 공백 메시지, 최대 길이 검사
+type LengthStage struct {
+	maxLength int
+}
+
+func NewLengthStage(maxLength int) *LengthStage {
+	return &LengthStage{maxLength: maxLength}
+}
+
+func (s *LengthStage) Name() string {
+	return "length"
+}
+
+func (s *LengthStage) Check(input Input) Verdict {
+	message := strings.TrimSpace(input.Message)
+	if message == "" {
+		return Verdict{Action: ActionReject, Reason: "빈 메시지는 보낼 수 없습니다"}
+	}
+
+	if utf8.RuneCountInString(message) > s.maxLength {
+		return Verdict{Action: ActionReject, Reason: fmt.Sprintf("메시지는 %d자를 넘을 수 없습니다", s.maxLength)}
+	}
+
+	return Verdict{Action: ActionAllow, Message: message}
+}
+
+// BannedWordStage - 금칙어를 *로 마스킹
+type BannedWordStage struct {
+	patterns []*regexp.Regexp
+}
+
+func NewBannedWordStage(words []string) *BannedWordStage {
+	var patterns []*regexp.Regexp
+	for _, word := range words {
+		patterns = append(patterns, regexp.MustCompile("(?i)"+regexp.QuoteMeta(word)))
+	}
+	return &BannedWordStage{patterns: patterns}
+}
+
+func (s *BannedWordStage) Name() string {
+	return "banned_word"
+}
+
+func (s *BannedWordStage) Check(input Input) Verdict {
+	message := input.Message
+	masked := false
+
+	for _, pattern := range s.patterns {
+		if pattern.MatchString(message) {
+			message = pattern.ReplaceAllStringFunc(message, maskText)
+			masked = true
+		}
+	}
+
+	if masked {
+		return Verdict{Action: ActionMask, Message: message, Reason: "부적절한 표현이 포함되어 가려졌습니다"}
+	}
+	return Verdict{Action: ActionAllow, Message: message}
+}
+
+var (
+	// 010-1234-5678, 010 1234 5678, 01012345678 등
+	phonePattern = regexp.MustCompile(`01[016789][\s.\-]?\d{3,4}[\s.\-]?\d{4}`)
+	// 카톡 아이디 후보: 카톡 abc123, 카카오톡 id abc, kakao: abc 등 (구분자 그룹 2, 아이디 그룹 3)
+	// "카톡 hello" 같은 일반 대화는 maskKakaoIDs에서 아이디 표시나 숫자/밑줄이 있을 때만 가림
+	kakaoIDPattern = regexp.MustCompile(`(?i)(카톡|카카오톡|카카오|kakao(?:talk)?|katalk)(\s*아이디\s*[:：]?\s*|\s*id(?:\s*[:：]\s*|\s+)|\s*[:：]\s*|\s+)([a-z0-9._\-]{4,20})`)
+	// 카카오 오픈채팅 링크
+	kakaoLinkPattern = regexp.MustCompile(`(?i)(https?://)?open\.kakao\.com/\S+`)
+)
+
+// ContactStage - 익명 게임 진행 중 전화번호, 카카오톡 ID 공유 차단
+type ContactStage struct{}
+
+func NewContactStage() *ContactStage {
+	return &ContactStage{}
+}
+
+func (s *ContactStage) Name() string {
+	return "contact"
+}
+
+func (s *ContactStage) Check(input Input) Verdict {
+	// 커플 채팅방이나 종료된 게임방은 익명 게임이 아니므로 검사하지 않음
+	if input.RoomType != commontype.MATCH_GAME || input.RoomStatus >= commontype.RoomStatusGameEnd {
+		return Verdict{Action: ActionAllow, Message: input.Message}
+	}
+
+	message := input.Message
+	masked := false
+
+	for _, pattern := range []*regexp.Regexp{kakaoLinkPattern, phonePattern} {
+		if pattern.MatchString(message) {
+			message = pattern.ReplaceAllStringFunc(message, maskText)
+			masked = true
+		}
+	}
+
+	message, kakaoMasked := maskKakaoIDs(message)
+	masked = masked || kakaoMasked
+
+	if masked {
+		return Verdict{Action: ActionMask, Message: message, Reason: "게임 중에는 연락처를 공유할 수 없습니다"}
+	}
+	return Verdict{Action: ActionAllow, Message: message}
+}
+
+// maskKakaoIDs - 아이디 표시(아이디, id, 콜론)가 있거나 숫자/밑줄이 섞인 카톡 아이디만 가림
+func maskKakaoIDs(message string) (string, bool) {
+	matches := kakaoIDPattern.FindAllStringSubmatchIndex(message, -1)
+	if matches == nil {
+		return message, false
+	}
+
+	var b strings.Builder
+	last := 0
+	masked := false
+	for _, m := range matches {
+		separator := strings.TrimSpace(message[m[4]:m[5]])
+		handle := message[m[6]:m[7]]
+		if separator == "" && !strings.ContainsAny(handle, "0123456789_") {
+			continue
+		}
+
+		b.WriteString(message[last:m[0]])
+		b.WriteString(maskText(message[m[0]:m[1]]))
+		last = m[1]
+		masked = true
+	}
+	b.WriteString(message[last:])
+
+	return b.String(), masked
+}
+
+func maskText(text string) string {
+	return strings.Repeat("*", utf8.RuneCountInString(text))
+}
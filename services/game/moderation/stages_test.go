@@ -0,0 +1,158 @@
+package moderation
+
+import (
+	"testing"
+
+	"solo/pkg/types/commontype"
+)
+
+func TestClosedRoomStage(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  Input
+		action Action
+	}{
+		{"진행 중인 커플방", Input{RoomType: commontype.MATCH_COUPLE, RoomStatus: commontype.RoomStatusGameIng, Message: "안녕"}, ActionAllow},
+		{"종료된 커플방", Input{RoomType: commontype.MATCH_COUPLE, RoomStatus: commontype.RoomStatusGameEnd, Message: "안녕"}, ActionReject},
+		{"종료된 게임방", Input{RoomType: commontype.MATCH_GAME, RoomStatus: commontype.RoomStatusGameEnd, Message: "안녕"}, ActionAllow},
+	}
+
+	stage := NewClosedRoomStage()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := stage.Check(tt.input).Action; got != tt.action {
+				t.Errorf("action = %s, want %s", got, tt.action)
+			}
+		})
+	}
+}
+
+func TestLengthStage(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		action  Action
+		want    string
+	}{
+		{"빈 메시지", "", ActionReject, ""},
+		{"공백만 있는 메시지", "  \n\t ", ActionReject, ""},
+		{"앞뒤 공백 제거", "  안녕하세요 ", ActionAllow, "안녕하세요"},
+		{"최대 길이", "가나다라마", ActionAllow, "가나다라마"},
+		{"최대 길이 초과", "가나다라마바", ActionReject, ""},
+	}
+
+	stage := NewLengthStage(5)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verdict := stage.Check(Input{Message: tt.message})
+			if verdict.Action != tt.action {
+				t.Fatalf("action = %s, want %s", verdict.Action, tt.action)
+			}
+			if verdict.Action == ActionAllow && verdict.Message != tt.want {
+				t.Errorf("message = %q, want %q", verdict.Message, tt.want)
+			}
+		})
+	}
+}
+
+func TestBannedWordStage(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		action  Action
+		want    string
+	}{
+		{"금칙어 없음", "반가워요", ActionAllow, "반가워요"},
+		{"금칙어 마스킹", "이런 시발 진짜", ActionMask, "이런 ** 진짜"},
+		{"대소문자 무시", "BadWord here", ActionMask, "******* here"},
+		{"여러 금칙어", "시발 badword", ActionMask, "** *******"},
+	}
+
+	stage := NewBannedWordStage([]string{"시발", "badword"})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verdict := stage.Check(Input{Message: tt.message})
+			if verdict.Action != tt.action {
+				t.Fatalf("action = %s, want %s", verdict.Action, tt.action)
+			}
+			if verdict.Message != tt.want {
+				t.Errorf("message = %q, want %q", verdict.Message, tt.want)
+			}
+		})
+	}
+}
+
+func TestContactStage(t *testing.T) {
+	tests := []struct {
+		name     string
+		roomType int
+		status   int
+		message  string
+		action   Action
+		want     string
+	}{
+		{"일반 대화", commontype.MATCH_GAME, commontype.RoomStatusGameIng, "카톡 hello", ActionAllow, "카톡 hello"},
+		{"카카오 뒤 한글", commontype.MATCH_GAME, commontype.RoomStatusGameIng, "kakao 좋아", ActionAllow, "kakao 좋아"},
+		{"카카오톡 뒤 한글", commontype.MATCH_GAME, commontype.RoomStatusGameIng, "kakaotalk 좋아", ActionAllow, "kakaotalk 좋아"},
+		{"id가 포함된 단어", commontype.MATCH_GAME, commontype.RoomStatusGameIng, "kakao idealist", ActionAllow, "kakao idealist"},
+		{"아이디 표시", commontype.MATCH_GAME, commontype.RoomStatusGameIng, "카카오톡 아이디 hello", ActionMask, "**************"},
+		{"id 표시", commontype.MATCH_GAME, commontype.RoomStatusGameIng, "카톡 id hello", ActionMask, "***********"},
+		{"콜론 표시", commontype.MATCH_GAME, commontype.RoomStatusGameIng, "kakao: hello", ActionMask, "************"},
+		{"숫자가 섞인 아이디", commontype.MATCH_GAME, commontype.RoomStatusGameIng, "카톡 abc123 추가해", ActionMask, "********* 추가해"},
+		{"밑줄이 섞인 아이디", commontype.MATCH_GAME, commontype.RoomStatusGameIng, "카톡 my_id", ActionMask, "********"},
+		{"전화번호", commontype.MATCH_GAME, commontype.RoomStatusGameIng, "010-1234-5678로 연락해", ActionMask, "*************로 연락해"},
+		{"오픈채팅 링크", commontype.MATCH_GAME, commontype.RoomStatusGameIng, "https://open.kakao.com/o/abc", ActionMask, "****************************"},
+		{"커플방은 검사 안 함", commontype.MATCH_COUPLE, commontype.RoomStatusGameIng, "010-1234-5678", ActionAllow, "010-1234-5678"},
+		{"종료된 게임방은 검사 안 함", commontype.MATCH_GAME, commontype.RoomStatusGameEnd, "카톡 abc123", ActionAllow, "카톡 abc123"},
+	}
+
+	stage := NewContactStage()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verdict := stage.Check(Input{RoomType: tt.roomType, RoomStatus: tt.status, Message: tt.message})
+			if verdict.Action != tt.action {
+				t.Fatalf("action = %s, want %s", verdict.Action, tt.action)
+			}
+			if verdict.Message != tt.want {
+				t.Errorf("message = %q, want %q", verdict.Message, tt.want)
+			}
+		})
+	}
+}
+
+func TestPipelineRun(t *testing.T) {
+	pipeline := NewPipeline(
+		NewClosedRoomStage(),
+		NewLengthStage(20),
+		NewBannedWordStage([]string{"시발"}),
+		NewContactStage(),
+	)
+
+	tests := []struct {
+		name   string
+		input  Input
+		action Action
+		stage  string
+		want   string
+	}{
+		{"통과", Input{RoomType: commontype.MATCH_GAME, RoomStatus: commontype.RoomStatusGameIng, Message: " 안녕 "}, ActionAllow, "", "안녕"},
+		{"빈 메시지 거절", Input{RoomType: commontype.MATCH_GAME, RoomStatus: commontype.RoomStatusGameIng, Message: " "}, ActionReject, "length", ""},
+		{"첫 마스킹 단계 유지", Input{RoomType: commontype.MATCH_GAME, RoomStatus: commontype.RoomStatusGameIng, Message: "시발 카톡 abc123"}, ActionMask, "banned_word", "** *********"},
+		{"종료된 커플방 거절", Input{RoomType: commontype.MATCH_COUPLE, RoomStatus: commontype.RoomStatusGameEnd, Message: "안녕"}, ActionReject, "closed_room", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := pipeline.Run(tt.input)
+			if result.Action != tt.action {
+				t.Fatalf("action = %s, want %s", result.Action, tt.action)
+			}
+			if result.Stage != tt.stage {
+				t.Errorf("stage = %q, want %q", result.Stage, tt.stage)
+			}
+			if result.Message != tt.want {
+				t.Errorf("message = %q, want %q", result.Message, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,268 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"solo/pkg/types/commontype"
+	eventtypes "solo/pkg/types/eventtype"
+	"solo/pkg/utils/stype"
+
+	"github.com/samber/lo"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// RoomActivity - 게임방 안에서 진행되는 활동 (밸런스 게임, 퀴즈 등)
+type RoomActivity interface {
+	// Name - 스케줄과 소켓 메시지에서 사용하는 활동 이름
+	Name() string
+	// Start - 활동 시작, 인스턴스 ID와 진행 시간 반환 (진행 시간이 0이면 종료 예약 없음)
+	Start(roomID string) (instanceID string, duration time.Duration, err error)
+	// HandleInput - 진행 중인 활동에 대한 사용자 입력 처리
+	HandleInput(roomID, instanceID string, userID int, data json.RawMessage) error
+	// Finish - 활동 종료 처리 (결과 전송 포함)
+	Finish(roomID, instanceID string) error
+}
+
+// ActivityRegistry - 이름으로 활동을 찾기 위한 레지스트리
+type ActivityRegistry struct {
+	mu         sync.RWMutex
+	activities map[string]RoomActivity
+}
+
+func NewActivityRegistry() *ActivityRegistry {
+	return &ActivityRegistry{activities: make(map[string]RoomActivity)}
+}
+
+func (r *ActivityRegistry) Register(activity RoomActivity) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.activities[activity.Name()] = activity
+	log.Printf("🧩 Room activity registered: %s", activity.Name())
+}
+
+func (r *ActivityRegistry) Get(name string) (RoomActivity, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	activity, ok := r.activities[name]
+	return activity, ok
+}
+
+// RegisterActivity - 새로운 방 활동 등록
+func (s *GameService) RegisterActivity(activity RoomActivity) {
+	s.activities.Register(activity)
+}
+
+// HandleActivityInput - 소켓으로 들어온 활동 입력을 해당 활동으로 전달
+func (s *GameService) HandleActivityInput(userID int, msg stype.ActivityInputMessage) error {
+	activity, ok := s.activities.Get(msg.Activity)
+	if !ok {
+		return fmt.Errorf("❌ Unknown activity: %s", msg.Activity)
+	}
+
+	roomUserIDs, err := s.redisClient.GetRoomUserIDs(msg.RoomID)
+	if err != nil {
+		return fmt.Errorf("❌ Redis GetRoomUserIDs 실패: %w", err)
+	}
+	if !lo.Contains(roomUserIDs, fmt.Sprintf("%d", userID)) {
+		return fmt.Errorf("❌ User %d is not a member of room %s", userID, msg.RoomID)
+	}
+
+	return activity.HandleInput(msg.RoomID, msg.InstanceID, userID, msg.Data)
+}
+
+// MigrateLegacyBalanceGameTimers - 활동 스케줄 도입 전에 예약된 밸런스 게임 시작/종료를 활동 스케줄로 옮김
+// 배포 시점에 진행 중이던 방의 밸런스 게임이 시작되지 않거나 투표가 끝나지 않은 채 남지 않도록 시작 시 실행
+func (s *GameService) MigrateLegacyBalanceGameTimers() {
+	migrated := 0
+
+	for {
+		roomID, remaining, err := s.redisClient.PopLegacyBalanceGameStart()
+		if err != nil {
+			log.Printf("Failed to pop legacy balance game start: %v", err)
+			break
+		}
+		if roomID == "" {
+			break
+		}
+
+		err = s.redisClient.ScheduleActivityJob(commontype.ActivityJob{
+			Activity: commontype.ActivityBalanceGame,
+			Phase:    commontype.ActivityPhaseStart,
+			RoomID:   roomID,
+		}, time.Now().Add(remaining))
+		if err != nil {
+			log.Printf("Failed to migrate legacy balance game start of room %s: %v", roomID, err)
+			continue
+		}
+		migrated++
+	}
+
+	for {
+		formID, remaining, err := s.redisClient.PopLegacyBalanceGameFinish()
+		if err != nil {
+			log.Printf("Failed to pop legacy balance game finish: %v", err)
+			break
+		}
+		if formID == "" {
+			break
+		}
+
+		objectID, err := primitive.ObjectIDFromHex(formID)
+		if err != nil {
+			log.Printf("⚠️ Invalid legacy balance form ID %s: %v", formID, err)
+			continue
+		}
+
+		roomID, err := s.chatRepo.GetRoomIdByBalanceFormID(objectID)
+		if err != nil {
+			log.Printf("Failed to get room of legacy balance form %s: %v", formID, err)
+			continue
+		}
+
+		err = s.redisClient.ScheduleActivityJob(commontype.ActivityJob{
+			Activity:   commontype.ActivityBalanceGame,
+			Phase:      commontype.ActivityPhaseFinish,
+			RoomID:     roomID,
+			InstanceID: formID,
+		}, time.Now().Add(remaining))
+		if err != nil {
+			log.Printf("Failed to migrate legacy balance game finish of form %s: %v", formID, err)
+			continue
+		}
+		migrated++
+	}
+
+	if migrated > 0 {
+		log.Printf("🧩 Migrated %d legacy balance game timers to the activity schedule", migrated)
+	}
+}
+
+// 예약된 방 활동 모니터링
+func (s *GameService) MonitorActivitySchedule() {
+	ticker := time.NewTicker(3 * time.Second)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		jobs, err := s.redisClient.PopDueActivityJobs(time.Now())
+		if err != nil {
+			log.Printf("Failed to get due activity jobs: %v", err)
+			continue
+		}
+
+		for _, job := range jobs {
+			s.runActivityJob(job)
+		}
+	}
+}
+
+func (s *GameService) runActivityJob(job commontype.ActivityJob) {
+	activity, ok := s.activities.Get(job.Activity)
+	if !ok {
+		log.Printf("⚠️ No activity registered for %s, room %s", job.Activity, job.RoomID)
+		return
+	}
+
+	switch job.Phase {
+	case commontype.ActivityPhaseStart:
+		// 대화 중인 방에서만 활동 시작
+		status, err := s.redisClient.GetRoomStatus(job.RoomID)
+		if err != nil || status != commontype.RoomStatusGameIng {
+			log.Printf("⚠️ Room %s is not in active game state, skipping activity %s", job.RoomID, job.Activity)
+			return
+		}
+
+		instanceID, duration, err := activity.Start(job.RoomID)
+		if err != nil {
+			log.Printf("Failed to start activity %s in room %s: %v", job.Activity, job.RoomID, err)
+			return
+		}
+
+		if duration <= 0 {
+			return
+		}
+
+		err = s.redisClient.ScheduleActivityJob(commontype.ActivityJob{
+			Activity:   job.Activity,
+			Phase:      commontype.ActivityPhaseFinish,
+			RoomID:     job.RoomID,
+			InstanceID: instanceID,
+		}, time.Now().Add(duration))
+		if err != nil {
+			log.Printf("Failed to schedule finish of activity %s in room %s: %v", job.Activity, job.RoomID, err)
+		}
+	case commontype.ActivityPhaseFinish:
+		err := activity.Finish(job.RoomID, job.InstanceID)
+		if err != nil {
+			log.Printf("Failed to finish activity %s in room %s (attempt %d): %v", job.Activity, job.RoomID, job.Attempt+1, err)
+			s.retryActivityFinish(job)
+			return
+		}
+
+		log.Printf("🧩 Activity %s finished in room %s", job.Activity, job.RoomID)
+	default:
+		log.Printf("⚠️ Unknown activity phase %s for %s, room %s", job.Phase, job.Activity, job.RoomID)
+	}
+}
+
+// retryActivityFinish - 종료 처리에 실패한 활동을 잠시 후 다시 예약 (결과 메시지 유실 방지)
+func (s *GameService) retryActivityFinish(job commontype.ActivityJob) {
+	if job.Attempt+1 >= commontype.ActivityFinishMaxAttempts {
+		log.Printf("⚠️ Giving up finishing activity %s in room %s after %d attempts", job.Activity, job.RoomID, job.Attempt+1)
+		return
+	}
+
+	job.Attempt++
+	err := s.redisClient.ScheduleActivityJob(job, time.Now().Add(commontype.ActivityFinishRetryDelay))
+	if err != nil {
+		log.Printf("Failed to reschedule finish of activity %s in room %s: %v", job.Activity, job.RoomID, err)
+	}
+}
+
+// publishSystemChat - 방에 시스템(마스터) 메시지 발행
+func (s *GameService) publishSystemChat(roomID, chatType, message string, decorate func(*eventtypes.ChatEvent)) (*eventtypes.ChatEvent, error) {
+	// Redis에서 비활성 사용자 목록 조회
+	inactiveUserIDs, err := s.redisClient.GetInActiveUserIDs(roomID)
+	if err != nil {
+		return nil, fmt.Errorf("❌ Redis GetInActiveUserIDs 실패: %w", err)
+	}
+
+	// 방에 접속해있는 사용자 ID 리스트 가져오기
+	joinedUserIDs, err := s.redisClient.GetJoinedUser(roomID)
+	if err != nil {
+		return nil, fmt.Errorf("❌ Redis GetJoinedUser 실패: %w", err)
+	}
+
+	headCnt, err := s.redisClient.GetRoomUserIDs(roomID)
+	if err != nil {
+		return nil, fmt.Errorf("❌ Redis GetRoomUserIDs 실패: %w", err)
+	}
+
+	chatEvent := eventtypes.ChatEvent{
+		MessageId:       primitive.NewObjectID(),
+		Type:            chatType,
+		RoomID:          roomID,
+		SenderID:        commontype.MasterID,
+		Message:         message,
+		UnreadCount:     len(headCnt) - len(joinedUserIDs),
+		InactiveUserIds: inactiveUserIDs,
+		ReaderIds:       joinedUserIDs,
+		CreatedAt:       time.Now(),
+	}
+	if decorate != nil {
+		decorate(&chatEvent)
+	}
+
+	// RabbitMQ를 통해 메시지 전송
+	err = s.emitter.PublishChatMessageEvent(chatEvent)
+	if err != nil {
+		return nil, fmt.Errorf("❌ RabbitMQ PublishChatMessageEvent 실패: %w", err)
+	}
+
+	return &chatEvent, nil
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+type stubActivity struct {
+	name     string
+	duration time.Duration
+}
+
+func (a *stubActivity) Name() string { return a.name }
+
+func (a *stubActivity) Start(roomID string) (string, time.Duration, error) {
+	return roomID + ":" + a.name, a.duration, nil
+}
+
+func (a *stubActivity) HandleInput(roomID, instanceID string, userID int, data json.RawMessage) error {
+	return nil
+}
+
+func (a *stubActivity) Finish(roomID, instanceID string) error { return nil }
+
+func TestActivityRegistry(t *testing.T) {
+	registry := NewActivityRegistry()
+
+	if _, ok := registry.Get("quiz"); ok {
+		t.Fatal("empty registry returned an activity")
+	}
+
+	quiz := &stubActivity{name: "quiz", duration: time.Minute}
+	registry.Register(quiz)
+	registry.Register(&stubActivity{name: "balance_game"})
+
+	got, ok := registry.Get("quiz")
+	if !ok || got != quiz {
+		t.Fatalf("Get(quiz) = %v, %t, want registered quiz", got, ok)
+	}
+
+	// 같은 이름으로 다시 등록하면 교체
+	replacement := &stubActivity{name: "quiz", duration: 2 * time.Minute}
+	registry.Register(replacement)
+	if got, _ := registry.Get("quiz"); got != replacement {
+		t.Errorf("Get(quiz) after re-register = %v, want replacement", got)
+	}
+
+	if _, ok := registry.Get("balance_game"); !ok {
+		t.Error("re-registering quiz removed balance_game")
+	}
+}
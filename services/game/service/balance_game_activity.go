@@ -0,0 +1,248 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"solo/pkg/logger"
+	"solo/pkg/models"
+	"solo/pkg/types/commontype"
+	eventtypes "solo/pkg/types/eventtype"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// BalanceGameActivity - 밸런스 게임 활동
+type BalanceGameActivity struct {
+	s *GameService
+}
+
+// balanceGameInput - 소켓으로 들어오는 밸런스 게임 투표 입력
+type balanceGameInput struct {
+	Choiced int  `json:"choiced"`
+	Cancel  bool `json:"cancel"`
+}
+
+func NewBalanceGameActivity(s *GameService) *BalanceGameActivity {
+	return &BalanceGameActivity{s: s}
+}
+
+func (a *BalanceGameActivity) Name() string {
+	return commontype.ActivityBalanceGame
+}
+
+// Start - 랜덤 밸런스 게임 폼 생성 후 시작 메시지 전송
+func (a *BalanceGameActivity) Start(roomID string) (string, time.Duration, error) {
+	// 밸런스 게임 랜덤 획득
+	balanceGame, err := a.s.chatRepo.GetRandomBalanceGameForm()
+	if err != nil {
+		return "", 0, fmt.Errorf("❌ GetRandomBalanceGameForm 실패: %w", err)
+	}
+
+	balanceGameForm := &models.BalanceGameForm{
+		Question: models.Question{
+			Title: balanceGame.Title,
+			Red:   balanceGame.Red,
+			Blue:  balanceGame.Blue,
+		},
+		RoomID: roomID,
+	}
+
+	// 밸런스 게임 폼 저장
+	formID, err := a.s.chatRepo.InsertBalanceForm(balanceGameForm)
+	if err != nil {
+		return "", 0, fmt.Errorf("❌ InsertBalanceForm 실패: %w", err)
+	}
+
+	chatEvent, err := a.s.publishSystemChat(roomID, commontype.ChatTypeForm, "밸런스 게임을 시작합니다!", func(e *eventtypes.ChatEvent) {
+		e.BalanceFormID = formID
+	})
+	if err != nil {
+		return "", 0, err
+	}
+
+	logger.Info(logger.LogEventBalanceGameStart, fmt.Sprintf("Balance game start: %s", roomID), chatEvent)
+	return formID.Hex(), commontype.BalanceGameEndTimer, nil
+}
+
+// HandleInput - 밸런스 게임 투표/투표 취소
+func (a *BalanceGameActivity) HandleInput(roomID, instanceID string, userID int, data json.RawMessage) error {
+	form, err := a.getForm(roomID, instanceID)
+	if err != nil {
+		return err
+	}
+
+	// 종료된 게임의 투표는 집계를 바꾸지 않도록 거부
+	if form.FinishedAt != nil {
+		return fmt.Errorf("❌ Balance form %s is already finished", instanceID)
+	}
+
+	var input balanceGameInput
+	if err := json.Unmarshal(data, &input); err != nil {
+		return fmt.Errorf("❌ 밸런스 게임 입력 파싱 실패: %w", err)
+	}
+
+	if input.Cancel {
+		return a.s.chatRepo.CancelVote(form.ID, userID)
+	}
+
+	if input.Choiced != commontype.BalanceFormVoteRed && input.Choiced != commontype.BalanceFormVoteBlue {
+		return fmt.Errorf("❌ Invalid balance game choice: %d", input.Choiced)
+	}
+
+	return a.s.chatRepo.InsertBalanceFormVote(&models.BalanceFormVote{
+		FormID:  form.ID,
+		UserID:  userID,
+		Choiced: input.Choiced,
+	})
+}
+
+// Finish - 승리 팀 집계 후 결과 메시지 전송 및 매치 히스토리 기록
+func (a *BalanceGameActivity) Finish(roomID, instanceID string) error {
+	form, err := a.getForm(roomID, instanceID)
+	if err != nil {
+		return err
+	}
+
+	// 종료 시점을 먼저 기록해 이후 투표를 막음 (재시도 시에는 이미 종료된 상태)
+	_, err = a.s.chatRepo.FinishBalanceForm(form.ID)
+	if err != nil {
+		return fmt.Errorf("❌ MongoDB FinishBalanceForm 실패: %w", err)
+	}
+
+	// 투표 마감과 별도로 결과 전송을 선점해 결과 메시지가 한 번만 나가도록 함
+	claimed, err := a.s.chatRepo.ClaimBalanceFormResult(form.ID)
+	if err != nil {
+		return fmt.Errorf("❌ MongoDB ClaimBalanceFormResult 실패: %w", err)
+	}
+	if !claimed {
+		log.Printf("⚠️ Result of balance form %s in room %s is already published", instanceID, roomID)
+		return nil
+	}
+
+	// 결과를 보내지 못했으면 선점을 풀어 재시도에서 다시 전송
+	published := false
+	defer func() {
+		if published {
+			return
+		}
+		if err := a.s.chatRepo.ReleaseBalanceFormResult(form.ID); err != nil {
+			log.Printf("Failed to release result of balance form %s: %v", instanceID, err)
+		}
+	}()
+
+	// 종료 전에 들어온 투표까지 반영된 집계로 다시 조회
+	form, err = a.getForm(roomID, instanceID)
+	if err != nil {
+		return err
+	}
+
+	room, err := a.s.chatRepo.GetRoomByID(roomID)
+	if err != nil {
+		return fmt.Errorf("❌ MongoDB GetRoomByID 실패: %w", err)
+	}
+	if room == nil {
+		return fmt.Errorf("❌ Room not found: %s", roomID)
+	}
+
+	result, err := a.buildResult(room, form)
+	if err != nil {
+		return err
+	}
+
+	chatEvent, err := a.s.publishSystemChat(roomID, commontype.ChatTypeFormResult, "밸런스 게임이 종료되었습니다!", func(e *eventtypes.ChatEvent) {
+		e.BalanceFormID = form.ID
+		e.BalanceResult = result
+	})
+	if err != nil {
+		return err
+	}
+	published = true
+
+	// 게임방만 매치 히스토리가 존재
+	if room.Type == commontype.MATCH_GAME {
+		err = a.s.chatRepo.UpdateMatchHistoryBalanceResult(int(room.Seq), models.BalanceGameResult{
+			GameID:     form.ID,
+			WinnerTeam: result.WinnerTeam,
+			RedCount:   result.Votes.RedCount,
+			BlueCount:  result.Votes.BlueCount,
+		})
+		if err != nil {
+			log.Printf("Failed to update balance result of match history, room seq %d: %v", room.Seq, err)
+		}
+	}
+
+	logger.Info(logger.LogEventBalanceGameEnd, fmt.Sprintf("Balance game end: %s", roomID), chatEvent)
+	return nil
+}
+
+// buildResult - 투표 기록으로 집계, 승리 팀, 참가자별 선택 계산
+func (a *BalanceGameActivity) buildResult(room *models.ChatRoom, form *models.BalanceGameForm) (*models.BalanceFormResult, error) {
+	votes, err := a.s.chatRepo.GetBalanceFormVotes(form.ID)
+	if err != nil {
+		return nil, fmt.Errorf("❌ MongoDB GetBalanceFormVotes 실패: %w", err)
+	}
+
+	choices := make(map[int]int, len(votes))
+	for _, vote := range votes {
+		choices[vote.UserID] = vote.Choiced
+	}
+
+	result := &models.BalanceFormResult{
+		Votes: form.Votes,
+		Sides: []models.BalanceFormSide{},
+	}
+
+	for _, gamer := range room.Gamers {
+		choiced, ok := choices[gamer.UserID]
+		if !ok {
+			choiced = commontype.BalanceFormVoteNone
+		}
+
+		result.Sides = append(result.Sides, models.BalanceFormSide{
+			UserID:        gamer.UserID,
+			CharacterName: gamer.CharacterName,
+			Choiced:       choiced,
+		})
+	}
+
+	result.WinnerTeam = balanceGameWinner(form.Votes)
+	return result, nil
+}
+
+func balanceGameWinner(votes models.Votes) int {
+	switch {
+	case votes.RedCount == 0 && votes.BlueCount == 0:
+		return commontype.BalanceGameWinnerNone
+	case votes.RedCount > votes.BlueCount:
+		return commontype.BalanceGameWinnerRed
+	case votes.BlueCount > votes.RedCount:
+		return commontype.BalanceGameWinnerBlue
+	default:
+		return commontype.BalanceGameWinnerTie
+	}
+}
+
+// getForm - 인스턴스 ID(폼 ID)로 폼을 조회하고 방 소속인지 확인
+func (a *BalanceGameActivity) getForm(roomID, instanceID string) (*models.BalanceGameForm, error) {
+	formID, err := primitive.ObjectIDFromHex(instanceID)
+	if err != nil {
+		return nil, fmt.Errorf("❌ Invalid balance form ID: %s", instanceID)
+	}
+
+	form, err := a.s.chatRepo.GetBalanceFormByID(formID)
+	if err != nil {
+		return nil, fmt.Errorf("❌ GetBalanceFormByID 실패: %w", err)
+	}
+	if form == nil {
+		return nil, fmt.Errorf("❌ Balance form not found: %s", instanceID)
+	}
+
+	if form.RoomID != roomID {
+		return nil, fmt.Errorf("❌ Balance form %s does not belong to room %s", instanceID, roomID)
+	}
+
+	return form, nil
+}
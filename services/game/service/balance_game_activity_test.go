@@ -0,0 +1,24 @@
+package service
+
+import (
+	"testing"
+
+	"solo/pkg/models"
+	"solo/pkg/types/commontype"
+)
+
+func TestBalanceGameWinner(t *testing.T) {
+	cases := map[models.Votes]int{
+		{RedCount: 0, BlueCount: 0}: commontype.BalanceGameWinnerNone,
+		{RedCount: 3, BlueCount: 1}: commontype.BalanceGameWinnerRed,
+		{RedCount: 0, BlueCount: 2}: commontype.BalanceGameWinnerBlue,
+		{RedCount: 2, BlueCount: 2}: commontype.BalanceGameWinnerTie,
+		{RedCount: 1, BlueCount: 0}: commontype.BalanceGameWinnerRed,
+	}
+
+	for votes, want := range cases {
+		if got := balanceGameWinner(votes); got != want {
+			t.Errorf("balanceGameWinner(red=%d, blue=%d) = %d, want %d", votes.RedCount, votes.BlueCount, got, want)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"solo/pkg/helper"
+	"solo/pkg/logger"
+	"solo/pkg/models"
+	"solo/pkg/redis"
+	"solo/pkg/types/commontype"
+	eventtypes "solo/pkg/types/eventtype"
+	"solo/pkg/utils/stype"
+
+	"github.com/samber/lo"
+)
+
+// ExchangeContact - 커플 채팅방 연락처 교환 (양쪽 모두 제출 및 확인해야 공개)
+func (s *GameService) ExchangeContact(userID int, msg stype.ContactExchangeMessage) error {
+	room, err := s.chatRepo.GetRoomByID(msg.RoomID)
+	if err != nil {
+		return fmt.Errorf("❌ MongoDB GetRoomByID 실패: %w", err)
+	}
+	if room == nil {
+		return fmt.Errorf("❌ Room not found: %s", msg.RoomID)
+	}
+	if !lo.Contains(room.UserIDs, userID) {
+		return fmt.Errorf("❌ User %d is not a member of room %s", userID, msg.RoomID)
+	}
+
+	status := stype.ContactExchangeStatusMessage{RoomID: room.ID, Required: len(room.UserIDs)}
+
+	reason, remaining, err := s.validateContactExchange(room, msg)
+	if err != nil {
+		return err
+	}
+	if reason != "" {
+		status.Reason = reason
+		return s.sendContactExchangeStatus(userID, status)
+	}
+
+	var state *redis.CoupleContactState
+	switch msg.Action {
+	case stype.ContactExchangeSubmit:
+		state, err = s.redisClient.SubmitCoupleContact(room.ID, userID, strings.TrimSpace(msg.Handle), remaining)
+	case stype.ContactExchangeConfirm:
+		state, err = s.redisClient.ConfirmCoupleContact(room.ID, userID, remaining)
+	case stype.ContactExchangeCancel:
+		err = s.redisClient.ClearCoupleContact(room.ID)
+		state = &redis.CoupleContactState{}
+		status.CancelledBy = userID
+	}
+	if err != nil {
+		return fmt.Errorf("❌ Redis 연락처 교환(%s) 실패: %w", msg.Action, err)
+	}
+
+	status.SubmittedUserIDs = lo.Keys(state.Handles)
+	status.ConfirmedUserIDs = state.ConfirmedUserIDs
+
+	if _, submitted := state.Handles[userID]; msg.Action == stype.ContactExchangeConfirm && !submitted {
+		status.Reason = "연락처를 먼저 제출해주세요"
+		return s.sendContactExchangeStatus(userID, status)
+	}
+
+	if msg.Action == stype.ContactExchangeConfirm && len(lo.Intersect(state.ConfirmedUserIDs, room.UserIDs)) == len(room.UserIDs) {
+		return s.revealContacts(room)
+	}
+
+	return s.SendMessageToRoom(room.ID, stype.WebSocketMessage{
+		Kind:    stype.MessageKindContactExchange,
+		Payload: helper.ToJSON(status),
+	})
+}
+
+// validateContactExchange - 연락처 교환 가능 여부 확인 후 남은 시간 반환, 불가하면 사유 반환
+func (s *GameService) validateContactExchange(room *models.ChatRoom, msg stype.ContactExchangeMessage) (string, time.Duration, error) {
+	if room.Type != commontype.MATCH_COUPLE {
+		return "커플 채팅방에서만 연락처를 교환할 수 있습니다", 0, nil
+	}
+
+	switch msg.Action {
+	case stype.ContactExchangeSubmit:
+		handle := strings.TrimSpace(msg.Handle)
+		if handle == "" {
+			return "연락처를 입력해주세요", 0, nil
+		}
+		if utf8.RuneCountInString(handle) > commontype.ContactHandleMaxLen {
+			return fmt.Sprintf("연락처는 %d자 이하로 입력해주세요", commontype.ContactHandleMaxLen), 0, nil
+		}
+	case stype.ContactExchangeConfirm, stype.ContactExchangeCancel:
+	default:
+		return "알 수 없는 요청입니다", 0, nil
+	}
+
+	remainingSeconds, err := s.redisClient.GetCoupleRoomRemainingTime(room.ID)
+	if err != nil {
+		return "", 0, fmt.Errorf("❌ Redis GetCoupleRoomRemainingTime 실패: %w", err)
+	}
+	if remainingSeconds <= 0 {
+		return "이미 종료된 채팅방입니다", 0, nil
+	}
+
+	return "", time.Duration(remainingSeconds) * time.Second, nil
+}
+
+// revealContacts - 제출된 연락처를 꺼내 양쪽에 공개하고 교환 기록
+func (s *GameService) revealContacts(room *models.ChatRoom) error {
+	handles, err := s.redisClient.TakeCoupleContacts(room.ID)
+	if err != nil {
+		return fmt.Errorf("❌ Redis TakeCoupleContacts 실패: %w", err)
+	}
+	// 다른 요청에서 이미 공개함
+	if len(handles) < len(room.UserIDs) {
+		return nil
+	}
+
+	revealed := stype.ContactRevealedMessage{RoomID: room.ID}
+	for _, id := range room.UserIDs {
+		revealed.Contacts = append(revealed.Contacts, stype.ContactHandle{UserID: id, Handle: handles[id]})
+	}
+
+	// 나중에 다시 볼 수 있도록 각자에게만 보이는 시스템 메시지로 상대 연락처 기록
+	for _, userID := range room.UserIDs {
+		others := lo.Filter(revealed.Contacts, func(c stype.ContactHandle, _ int) bool { return c.UserID != userID })
+		message := fmt.Sprintf("연락처 교환이 완료되었습니다. 상대방 연락처: %s",
+			strings.Join(lo.Map(others, func(c stype.ContactHandle, _ int) string { return c.Handle }), ", "))
+
+		_, err := s.publishSystemChat(room.ID, commontype.ChatTypeChat, message, func(e *eventtypes.ChatEvent) {
+			joined := lo.Contains(e.ReaderIds, userID)
+			e.VisibleTo = []int{userID}
+			e.ReaderIds = lo.Intersect(e.ReaderIds, []int{userID})
+			e.InactiveUserIds = lo.Intersect(e.InactiveUserIds, []int{userID})
+			e.UnreadCount = lo.Ternary(joined, 0, 1)
+		})
+		if err != nil {
+			log.Printf("Failed to publish contact reveal to user %d in room %s: %v", userID, room.ID, err)
+		}
+	}
+
+	// 연락처 내용은 남기지 않고 교환 사실만 기록
+	logger.Info(logger.LogEventCoupleContactExchange, fmt.Sprintf("Couple contact exchange: %s", room.ID), map[string]interface{}{
+		"room_id":     room.ID,
+		"user_ids":    room.UserIDs,
+		"revealed_at": time.Now(),
+	})
+
+	return s.SendMessageToRoom(room.ID, stype.WebSocketMessage{
+		Kind:    stype.MessageKindContactRevealed,
+		Payload: helper.ToJSON(revealed),
+	})
+}
+
+func (s *GameService) sendContactExchangeStatus(userID int, status stype.ContactExchangeStatusMessage) error {
+	return s.SendMessageToUser(userID, stype.WebSocketMessage{
+		Kind:    stype.MessageKindContactExchange,
+		Payload: helper.ToJSON(status),
+	})
+}
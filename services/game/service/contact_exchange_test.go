@@ -0,0 +1,39 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"solo/pkg/models"
+	"solo/pkg/types/commontype"
+	"solo/pkg/utils/stype"
+)
+
+// 요청 내용만으로 판단할 수 있는 거절은 남은 시간 조회 전에 반환
+func TestValidateContactExchangeRejectsInput(t *testing.T) {
+	s := &GameService{}
+	couple := &models.ChatRoom{ID: "room", Type: commontype.MATCH_COUPLE}
+
+	tests := []struct {
+		name string
+		room *models.ChatRoom
+		msg  stype.ContactExchangeMessage
+	}{
+		{"게임방", &models.ChatRoom{ID: "room", Type: commontype.MATCH_GAME}, stype.ContactExchangeMessage{Action: stype.ContactExchangeSubmit, Handle: "kakao"}},
+		{"공백뿐인 연락처", couple, stype.ContactExchangeMessage{Action: stype.ContactExchangeSubmit, Handle: "   "}},
+		{"너무 긴 연락처", couple, stype.ContactExchangeMessage{Action: stype.ContactExchangeSubmit, Handle: strings.Repeat("가", commontype.ContactHandleMaxLen+1)}},
+		{"알 수 없는 요청", couple, stype.ContactExchangeMessage{Action: "reveal"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason, remaining, err := s.validateContactExchange(tt.room, tt.msg)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if reason == "" || remaining != 0 {
+				t.Errorf("reason=%q remaining=%v, want rejection", reason, remaining)
+			}
+		})
+	}
+}
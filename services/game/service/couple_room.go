@@ -0,0 +1,240 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"solo/pkg/config"
+	"solo/pkg/helper"
+	"solo/pkg/models"
+	"solo/pkg/types/commontype"
+	eventtypes "solo/pkg/types/eventtype"
+	"solo/pkg/utils/stype"
+
+	"github.com/samber/lo"
+)
+
+// coupleExtendConfig - 커플 채팅방 대화 연장 설정
+type coupleExtendConfig struct {
+	window   time.Duration
+	duration time.Duration
+	maxCount int
+}
+
+func loadCoupleExtendConfig() coupleExtendConfig {
+	return coupleExtendConfig{
+		window:   config.GetDuration("COUPLE_EXTEND_WINDOW", commontype.CoupleExtendWindow),
+		duration: config.GetDuration("COUPLE_EXTEND_DURATION", commontype.CoupleExtendDuration),
+		maxCount: config.GetInt("COUPLE_EXTEND_MAX_COUNT", commontype.CoupleExtendMaxCount),
+	}
+}
+
+// 커플 채팅방 만료 모니터링 (최종 선택 흐름 없이 알림 → 종료)
+// 방 정보는 알림 구간이나 종료를 선점한 경우에만 조회
+func (s *GameService) MonitorCoupleRoomTimeouts() {
+	ticker := time.NewTicker(10 * time.Second)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		rooms, err := s.redisClient.GetAllCoupleRoomsFromRedis()
+		if err != nil {
+			log.Printf("Failed to fetch couple rooms for timeout monitoring: %v", err)
+			continue
+		}
+
+		for _, roomID := range rooms {
+			remainingTime, err := s.redisClient.GetCoupleRoomRemainingTime(roomID)
+			if err != nil {
+				continue
+			}
+
+			if remainingTime > 0 {
+				s.notifyCoupleRoomRemaining(roomID, remainingTime)
+				continue
+			}
+
+			// 여러 파드가 같은 방을 중복 종료하지 않도록 목록에서 먼저 빼는 쪽만 진행
+			claimed, err := s.redisClient.ClaimCoupleRoomClose(roomID)
+			if err != nil {
+				log.Printf("Failed to claim couple room %s close: %v", roomID, err)
+				continue
+			}
+			if !claimed {
+				continue
+			}
+
+			room, err := s.chatRepo.GetRoomByID(roomID)
+			if err != nil {
+				log.Printf("Failed to get couple room %s: %v", roomID, err)
+			} else if room != nil {
+				s.closeCoupleRoom(room)
+			}
+
+			err = s.redisClient.RemoveCoupleRoomFromRedis(roomID)
+			if err != nil {
+				log.Printf("Failed to remove expired couple room %s from Redis: %v", roomID, err)
+			}
+		}
+	}
+}
+
+// notifyCoupleRoomRemaining - 만료 알림 구간마다 한 번씩 알림 (푸시 대상 포함)
+func (s *GameService) notifyCoupleRoomRemaining(roomID string, remainingSeconds int) {
+	milestone := remainingMilestone(time.Duration(remainingSeconds)*time.Second, commontype.CoupleRemainingMilestones)
+	if milestone == 0 {
+		return
+	}
+
+	claimed, err := s.redisClient.ClaimRoomRemainingMilestone(roomID, commontype.RoomPhaseCouple, milestone)
+	if err != nil || !claimed {
+		return
+	}
+
+	room, err := s.chatRepo.GetRoomByID(roomID)
+	if err != nil {
+		log.Printf("Failed to get couple room %s: %v", roomID, err)
+		return
+	}
+	if room == nil {
+		// 이미 정리된 방
+		if err := s.redisClient.RemoveCoupleRoomFromRedis(roomID); err != nil {
+			log.Printf("Failed to remove couple room %s from Redis: %v", roomID, err)
+		}
+		return
+	}
+
+	err = s.emitter.PublishRoomRemainTimeEvent(eventtypes.RoomRemainTimeEvent{
+		RoomID:           room.ID,
+		Phase:            commontype.RoomPhaseCouple,
+		RemainingSeconds: remainingSeconds,
+		UserIDs:          room.UserIDs,
+	})
+	if err != nil {
+		log.Printf("Failed to publish couple room remain time event for RoomID %s: %v", room.ID, err)
+	}
+}
+
+// closeCoupleRoom - 커플 채팅방 종료 (읽기 전용 상태로 전환 후 보관 기간 뒤 정리)
+func (s *GameService) closeCoupleRoom(room *models.ChatRoom) {
+	_, err := s.publishSystemChat(room.ID, commontype.ChatTypeLeave, "대화 기간이 끝나 채팅방이 종료되었습니다.", nil)
+	if err != nil {
+		log.Printf("Failed to publish couple room close message for RoomID %s: %v", room.ID, err)
+	}
+
+	err = s.redisClient.SetRoomStatus(room.ID, commontype.RoomStatusGameEnd)
+	if err != nil {
+		log.Printf("Failed to set couple room %s status: %v", room.ID, err)
+	}
+
+	err = s.emitter.PublishCoupleRoomCloseEvent(eventtypes.CoupleRoomCloseEvent{
+		RoomID:   room.ID,
+		UserIDs:  room.UserIDs,
+		ClosedAt: time.Now(),
+	})
+	if err != nil {
+		log.Printf("Failed to publish couple room close event for RoomID %s: %v", room.ID, err)
+	}
+
+	log.Printf("💔 Couple room %s closed", room.ID)
+}
+
+// ExtendCoupleRoom - 커플 채팅방 대화 연장 동의/철회, 모두 동의하면 연장
+func (s *GameService) ExtendCoupleRoom(userID int, msg stype.CoupleExtendMessage) error {
+	room, err := s.chatRepo.GetRoomByID(msg.RoomID)
+	if err != nil {
+		return fmt.Errorf("❌ MongoDB GetRoomByID 실패: %w", err)
+	}
+	if room == nil {
+		return fmt.Errorf("❌ Room not found: %s", msg.RoomID)
+	}
+	if !lo.Contains(room.UserIDs, userID) {
+		return fmt.Errorf("❌ User %d is not a member of room %s", userID, msg.RoomID)
+	}
+
+	status := stype.CoupleExtendStatusMessage{RoomID: room.ID, Required: len(room.UserIDs)}
+
+	reason, remaining, err := s.validateCoupleExtend(room)
+	if err != nil {
+		return err
+	}
+	if reason != "" {
+		status.Reason = reason
+		return s.SendMessageToUser(userID, stype.WebSocketMessage{
+			Kind:    stype.MessageKindCoupleExtend,
+			Payload: helper.ToJSON(status),
+		})
+	}
+
+	agreedUserIDs, err := s.redisClient.SetCoupleExtendAgree(room.ID, userID, msg.Agree, remaining)
+	if err != nil {
+		return fmt.Errorf("❌ Redis SetCoupleExtendAgree 실패: %w", err)
+	}
+	status.AgreedUserIDs = agreedUserIDs
+
+	// 방 참가자 모두가 동의해야 연장 (동시에 들어온 동의 중 선점한 요청만 적용)
+	if len(lo.Intersect(agreedUserIDs, room.UserIDs)) == len(room.UserIDs) {
+		claimed, err := s.redisClient.ClaimCoupleExtend(room.ID)
+		if err != nil {
+			return fmt.Errorf("❌ Redis ClaimCoupleExtend 실패: %w", err)
+		}
+		if !claimed {
+			return nil
+		}
+
+		status, err = s.applyCoupleExtend(room, status)
+		if err != nil {
+			return err
+		}
+	}
+
+	return s.SendMessageToRoom(room.ID, stype.WebSocketMessage{
+		Kind:    stype.MessageKindCoupleExtend,
+		Payload: helper.ToJSON(status),
+	})
+}
+
+// validateCoupleExtend - 연장 동의 가능 여부 확인 후 남은 시간 반환, 불가하면 사유 반환
+func (s *GameService) validateCoupleExtend(room *models.ChatRoom) (string, time.Duration, error) {
+	if room.Type != commontype.MATCH_COUPLE {
+		return "커플 채팅방만 연장할 수 있습니다", 0, nil
+	}
+	if room.ExtendCount >= s.coupleExtend.maxCount {
+		return "더 이상 연장할 수 없습니다", 0, nil
+	}
+
+	remainingSeconds, err := s.redisClient.GetCoupleRoomRemainingTime(room.ID)
+	if err != nil {
+		return "", 0, fmt.Errorf("❌ Redis GetCoupleRoomRemainingTime 실패: %w", err)
+	}
+	remaining := time.Duration(remainingSeconds) * time.Second
+	if remaining <= 0 {
+		return "이미 종료된 채팅방입니다", 0, nil
+	}
+	if remaining > s.coupleExtend.window {
+		return fmt.Sprintf("종료 %d시간 전부터 연장할 수 있습니다", int(s.coupleExtend.window.Hours())), 0, nil
+	}
+
+	return "", remaining, nil
+}
+
+// applyCoupleExtend - MongoDB 종료 시각과 Redis 타이머 연장
+func (s *GameService) applyCoupleExtend(room *models.ChatRoom, status stype.CoupleExtendStatusMessage) (stype.CoupleExtendStatusMessage, error) {
+	finishChatAt := room.FinishChatAt.Add(s.coupleExtend.duration)
+
+	err := s.chatRepo.ExtendRoomTime(room.ID, finishChatAt, finishChatAt)
+	if err != nil {
+		return status, fmt.Errorf("❌ MongoDB ExtendRoomTime 실패: %w", err)
+	}
+
+	err = s.redisClient.SetCoupleRoomTimeout(room.ID, time.Until(finishChatAt))
+	if err != nil {
+		return status, fmt.Errorf("❌ Redis SetCoupleRoomTimeout 실패: %w", err)
+	}
+
+	log.Printf("💞 Couple room %s extended until %s", room.ID, finishChatAt.Format(time.RFC3339))
+
+	status.Extended = true
+	status.FinishChatAt = finishChatAt
+	return status, nil
+}
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"solo/pkg/models"
+	"solo/pkg/types/commontype"
+)
+
+func TestCoupleRemainingMilestones(t *testing.T) {
+	// 커플방 알림 구간: 24시간, 3시간, 30분
+	for remaining, want := range map[time.Duration]time.Duration{
+		48 * time.Hour:   0,
+		20 * time.Hour:   24 * time.Hour,
+		2 * time.Hour:    3 * time.Hour,
+		10 * time.Minute: 30 * time.Minute,
+	} {
+		if got := remainingMilestone(remaining, commontype.CoupleRemainingMilestones); got != want {
+			t.Errorf("remainingMilestone(%v) = %v, want %v", remaining, got, want)
+		}
+	}
+}
+
+// 방 정보만으로 판단할 수 있는 거절은 Redis 조회 없이 반환
+func TestValidateCoupleExtendRejectsWithoutTimer(t *testing.T) {
+	s := &GameService{coupleExtend: coupleExtendConfig{window: 24 * time.Hour, duration: 24 * time.Hour, maxCount: 2}}
+
+	reason, _, err := s.validateCoupleExtend(&models.ChatRoom{ID: "room", Type: commontype.MATCH_GAME})
+	if err != nil || reason == "" {
+		t.Errorf("game room: reason=%q err=%v, want rejection", reason, err)
+	}
+
+	reason, _, err = s.validateCoupleExtend(&models.ChatRoom{ID: "room", Type: commontype.MATCH_COUPLE, ExtendCount: 2})
+	if err != nil || reason != "더 이상 연장할 수 없습니다" {
+		t.Errorf("max extended room: reason=%q err=%v, want max count rejection", reason, err)
+	}
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"log"
+	"math/rand"
+	"time"
+
+	"solo/pkg/helper"
+	"solo/pkg/utils/stype"
+
+	"github.com/gorilla/websocket"
+)
+
+// IsDraining - 종료 준비 중이면 새 WebSocket 연결을 받지 않음
+func (s *GameService) IsDraining() bool {
+	return s.draining.Load()
+}
+
+// Drain - 서버 종료 전 연결된 클라이언트 정리
+// 1. 새 연결 차단 2. server_restart 전송 3. Send 버퍼 비우기 4. 연결 종료 5. Redis 활성 사용자 제거
+func (s *GameService) Drain(ctx context.Context, reconnectAfter time.Duration) {
+	s.draining.Store(true)
+	log.Printf("🛑 Draining game server, reconnect hint: %v", reconnectAfter)
+
+	var clients []*Client
+	s.clients.Range(func(key, value interface{}) bool {
+		client := value.(*Client)
+		clients = append(clients, client)
+
+		// 재접속이 한 번에 몰리지 않도록 클라이언트마다 대기 시간 분산
+		jitter := time.Duration(rand.Int63n(int64(reconnectAfter) + 1))
+		message := stype.WebSocketMessage{
+			Kind:    stype.MessageKindServerRestart,
+			Payload: helper.ToJSON(stype.ServerRestartMessage{ReconnectAfterMs: int((reconnectAfter + jitter).Milliseconds())}),
+		}
+
+		// 이미 연결이 끊겨 Send 채널이 닫힌 클라이언트는 건너뜀
+		client.Deliver(ctx, message)
+		return true
+	})
+
+	// Send 버퍼가 비워질 때까지 대기
+	s.waitForFlush(ctx, clients)
+
+	// 연결 종료 (읽기 고루틴이 종료되면서 UnRegisterUserFromGame 호출)
+	closeMessage := websocket.FormatCloseMessage(websocket.CloseServiceRestart, "server restart")
+	for _, client := range clients {
+		err := client.Conn.WriteControl(websocket.CloseMessage, closeMessage, time.Now().Add(time.Second))
+		if err != nil {
+			log.Printf("⚠️ Failed to send close frame: %v", err)
+		}
+		client.Conn.Close()
+	}
+
+	// 정상 해제되지 못한 사용자가 남지 않도록 파드의 활성 사용자 일괄 제거
+	count, err := s.redisClient.UnregisterServerActiveUsers(s.serverID)
+	if err != nil {
+		log.Printf("❌ Redis 활성 사용자 일괄 제거 실패: %v", err)
+		return
+	}
+
+	log.Printf("✅ Drain complete, clients: %d, unregistered: %d", len(clients), count)
+}
+
+func (s *GameService) waitForFlush(ctx context.Context, clients []*Client) {
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		pending := 0
+		for _, client := range clients {
+			pending += len(client.Send)
+		}
+		if pending == 0 {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Printf("⚠️ Drain deadline exceeded, %d messages not flushed", pending)
+			return
+		case <-ticker.C:
+		}
+	}
+}
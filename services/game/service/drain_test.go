@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func newTestClient(buffer int) *Client {
+	return &Client{Send: make(chan interface{}, buffer), Ctx: context.Background()}
+}
+
+func TestClientDeliverAfterClose(t *testing.T) {
+	client := newTestClient(1)
+	client.Close()
+	// 두 번 닫아도 panic 없이 무시
+	client.Close()
+
+	if client.Deliver(context.Background(), "late") {
+		t.Error("Deliver to closed client succeeded")
+	}
+}
+
+func TestClientDeliverStopsOnContext(t *testing.T) {
+	client := newTestClient(0) // 읽는 쪽이 없으면 계속 대기
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if client.Deliver(ctx, "message") {
+		t.Error("Deliver without a reader succeeded")
+	}
+}
+
+func TestWaitForFlush(t *testing.T) {
+	s := &GameService{}
+	client := newTestClient(2)
+	client.Send <- "pending"
+
+	// 클라이언트가 버퍼를 비우면 마감 전에 반환
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		<-client.Send
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	start := time.Now()
+	s.waitForFlush(ctx, []*Client{client})
+	if ctx.Err() != nil {
+		t.Fatalf("waitForFlush waited until deadline (%v) for a drained client", time.Since(start))
+	}
+
+	// 버퍼가 비워지지 않으면 마감 시각에 반환
+	client.Send <- "stuck"
+	deadline, cancelDeadline := context.WithTimeout(context.Background(), 150*time.Millisecond)
+	defer cancelDeadline()
+
+	s.waitForFlush(deadline, []*Client{client})
+	if deadline.Err() == nil {
+		t.Error("waitForFlush returned before the deadline with messages pending")
+	}
+}
@@ -0,0 +1,235 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"solo/pkg/helper"
+	"solo/pkg/logger"
+	"solo/pkg/models"
+	"solo/pkg/types/commontype"
+	eventtypes "solo/pkg/types/eventtype"
+	"solo/pkg/utils/stype"
+
+	"github.com/samber/lo"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// FirstImpressionActivity - 게임 중간의 첫인상 선택 라운드
+// 선택은 비공개로 저장되고, 각 참가자는 자신이 받은 선택 수만 확인
+type FirstImpressionActivity struct {
+	s *GameService
+}
+
+// firstImpressionInput - 소켓으로 들어오는 첫인상 선택 입력
+type firstImpressionInput struct {
+	SelectedUserID int `json:"selected_user_id"`
+}
+
+func NewFirstImpressionActivity(s *GameService) *FirstImpressionActivity {
+	return &FirstImpressionActivity{s: s}
+}
+
+func (a *FirstImpressionActivity) Name() string {
+	return commontype.ActivityFirstImpression
+}
+
+// Start - 진행 중인 라운드를 Redis에 기록하고 시작 메시지 전송
+func (a *FirstImpressionActivity) Start(roomID string) (string, time.Duration, error) {
+	room, err := a.s.chatRepo.GetRoomByID(roomID)
+	if err != nil {
+		return "", 0, fmt.Errorf("❌ MongoDB GetRoomByID 실패: %w", err)
+	}
+	if room == nil {
+		return "", 0, fmt.Errorf("❌ Room not found: %s", roomID)
+	}
+	if room.Type != commontype.MATCH_GAME {
+		return "", 0, fmt.Errorf("❌ First impression round is only for game rooms: %s", roomID)
+	}
+
+	instanceID := primitive.NewObjectID().Hex()
+	endsAt := time.Now().Add(commontype.FirstImpressionDuration)
+
+	// 입력은 이 기록과 일치할 때만 받음 (라운드 시간이 지나면 만료)
+	err = a.s.redisClient.SetActiveFirstImpression(roomID, instanceID, commontype.FirstImpressionDuration)
+	if err != nil {
+		return "", 0, fmt.Errorf("❌ Redis SetActiveFirstImpression 실패: %w", err)
+	}
+
+	chatEvent, err := a.s.publishSystemChat(roomID, commontype.ChatTypeChat, "첫인상 선택을 시작합니다! 가장 마음에 드는 상대를 골라주세요. 누가 선택했는지는 공개되지 않습니다.", nil)
+	if err != nil {
+		return "", 0, err
+	}
+
+	err = a.s.SendMessageToRoom(roomID, stype.WebSocketMessage{
+		Kind: stype.MessageKindFirstImpression,
+		Payload: helper.ToJSON(stype.FirstImpressionMessage{
+			RoomID:     roomID,
+			InstanceID: instanceID,
+			Phase:      commontype.ActivityPhaseStart,
+			EndsAt:     endsAt,
+		}),
+	})
+	if err != nil {
+		log.Printf("Failed to send first impression start to room %s: %v", roomID, err)
+	}
+
+	logger.Info(logger.LogEventFirstImpressionStart, fmt.Sprintf("First impression start: %s", roomID), chatEvent)
+	return instanceID, commontype.FirstImpressionDuration, nil
+}
+
+// HandleInput - 첫인상 선택 (라운드 종료 전까지 변경 가능)
+func (a *FirstImpressionActivity) HandleInput(roomID, instanceID string, userID int, data json.RawMessage) error {
+	var input firstImpressionInput
+	if err := json.Unmarshal(data, &input); err != nil {
+		return fmt.Errorf("❌ 첫인상 선택 입력 파싱 실패: %w", err)
+	}
+
+	reason, err := a.validateInput(roomID, instanceID, userID, input)
+	if err != nil {
+		return err
+	}
+
+	ack := stype.FirstImpressionMessage{
+		RoomID:         roomID,
+		InstanceID:     instanceID,
+		Phase:          "ack",
+		SelectedUserID: input.SelectedUserID,
+		Accepted:       reason == "",
+		Reason:         reason,
+	}
+
+	if reason == "" {
+		// 라운드가 끝난 뒤에도 결과 조회가 가능하도록 넉넉히 보관
+		err = a.s.redisClient.SaveFirstImpression(roomID, instanceID, userID, input.SelectedUserID, commontype.GameRunningTime)
+		if err != nil {
+			return fmt.Errorf("❌ Redis SaveFirstImpression 실패: %w", err)
+		}
+	}
+
+	return a.s.SendMessageToUser(userID, stype.WebSocketMessage{
+		Kind:    stype.MessageKindFirstImpression,
+		Payload: helper.ToJSON(ack),
+	})
+}
+
+// validateInput - 선택 가능 여부 확인, 불가하면 사유 반환
+func (a *FirstImpressionActivity) validateInput(roomID, instanceID string, userID int, input firstImpressionInput) (string, error) {
+	activeInstanceID, err := a.s.redisClient.GetActiveFirstImpression(roomID)
+	if err != nil {
+		return "", fmt.Errorf("❌ Redis GetActiveFirstImpression 실패: %w", err)
+	}
+	if activeInstanceID == "" || activeInstanceID != instanceID {
+		return "진행 중인 첫인상 선택이 아닙니다", nil
+	}
+
+	room, err := a.s.chatRepo.GetRoomByID(roomID)
+	if err != nil {
+		return "", fmt.Errorf("❌ MongoDB GetRoomByID 실패: %w", err)
+	}
+	if room == nil {
+		return "", fmt.Errorf("❌ Room not found: %s", roomID)
+	}
+
+	chooser := findGamer(room, userID)
+	if chooser == nil {
+		return "방 참가자가 아닙니다", nil
+	}
+	if input.SelectedUserID == userID {
+		return "자기 자신은 선택할 수 없습니다", nil
+	}
+
+	target := findGamer(room, input.SelectedUserID)
+	if target == nil {
+		return "선택한 상대가 방에 없습니다", nil
+	}
+	// 성별 정보 도입 전에 생성된 방은 모두 0으로 저장되어 있어 검사 생략
+	if hasGenderData(room) && target.Gender == chooser.Gender {
+		return "이성만 선택할 수 있습니다", nil
+	}
+
+	return "", nil
+}
+
+// Finish - 참가자별로 받은 선택 수를 비공개 메시지로 전송하고 매치 히스토리에 기록
+func (a *FirstImpressionActivity) Finish(roomID, instanceID string) error {
+	// 종료 이후 입력은 받지 않음
+	err := a.s.redisClient.ClearActiveFirstImpression(roomID, instanceID)
+	if err != nil {
+		log.Printf("Failed to clear active first impression of room %s: %v", roomID, err)
+	}
+
+	room, err := a.s.chatRepo.GetRoomByID(roomID)
+	if err != nil {
+		return fmt.Errorf("❌ MongoDB GetRoomByID 실패: %w", err)
+	}
+	if room == nil {
+		return fmt.Errorf("❌ Room not found: %s", roomID)
+	}
+
+	picks, err := a.s.redisClient.GetFirstImpressions(roomID, instanceID)
+	if err != nil {
+		return fmt.Errorf("❌ Redis GetFirstImpressions 실패: %w", err)
+	}
+
+	received := receivedCounts(picks)
+
+	for _, gamer := range room.Gamers {
+		userID := gamer.UserID
+		count := received[userID]
+
+		// 본인에게만 보이는 시스템 메시지로 결과 기록
+		_, err := a.s.publishSystemChat(roomID, commontype.ChatTypeChat, fmt.Sprintf("첫인상 선택에서 %d표를 받았습니다.", count), func(e *eventtypes.ChatEvent) {
+			joined := lo.Contains(e.ReaderIds, userID)
+			e.VisibleTo = []int{userID}
+			e.ReaderIds = lo.Intersect(e.ReaderIds, []int{userID})
+			e.InactiveUserIds = lo.Intersect(e.InactiveUserIds, []int{userID})
+			e.UnreadCount = lo.Ternary(joined, 0, 1)
+		})
+		if err != nil {
+			log.Printf("Failed to publish first impression result to user %d in room %s: %v", userID, roomID, err)
+		}
+	}
+
+	err = a.s.SendTailoredMessageToRoom(roomID, func(userID int) stype.WebSocketMessage {
+		return stype.WebSocketMessage{
+			Kind: stype.MessageKindFirstImpression,
+			Payload: helper.ToJSON(stype.FirstImpressionMessage{
+				RoomID:        roomID,
+				InstanceID:    instanceID,
+				Phase:         commontype.ActivityPhaseFinish,
+				ReceivedCount: received[userID],
+			}),
+		}
+	})
+	if err != nil {
+		log.Printf("Failed to send first impression result to room %s: %v", roomID, err)
+	}
+
+	result := models.FirstImpressionResult{
+		RoundID:  instanceID,
+		Picks:    []models.FirstImpressionPick{},
+		FinishAt: time.Now(),
+	}
+	for userID, selectedUserID := range picks {
+		result.Picks = append(result.Picks, models.FirstImpressionPick{UserID: userID, SelectedUserID: selectedUserID})
+	}
+
+	err = a.s.chatRepo.UpdateMatchHistoryFirstImpression(int(room.Seq), result)
+	if err != nil {
+		log.Printf("Failed to update first impression result of match history, room seq %d: %v", room.Seq, err)
+	}
+
+	logger.Info(logger.LogEventFirstImpressionEnd, fmt.Sprintf("First impression end: %s", roomID), result)
+	return nil
+}
+
+func receivedCounts(picks map[int]int) map[int]int {
+	received := make(map[int]int)
+	for _, selectedUserID := range picks {
+		received[selectedUserID]++
+	}
+	return received
+}
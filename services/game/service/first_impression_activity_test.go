@@ -0,0 +1,29 @@
+package service
+
+import "testing"
+
+func TestReceivedCounts(t *testing.T) {
+	// key: 선택한 사람, value: 선택받은 사람
+	picks := map[int]int{1: 4, 2: 3, 3: 4, 4: 1}
+
+	received := receivedCounts(picks)
+
+	want := map[int]int{1: 1, 2: 0, 3: 1, 4: 2}
+	for userID, count := range want {
+		if received[userID] != count {
+			t.Errorf("user %d received %d, want %d", userID, received[userID], count)
+		}
+	}
+
+	total := 0
+	for _, count := range received {
+		total += count
+	}
+	if total != len(picks) {
+		t.Errorf("total received = %d, want one per pick (%d)", total, len(picks))
+	}
+
+	if received := receivedCounts(nil); len(received) != 0 {
+		t.Errorf("receivedCounts(nil) = %v, want empty", received)
+	}
+}
@@ -6,8 +6,10 @@ import (
 	"fmt"
 	"log"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"solo/pkg/config"
 	"solo/pkg/helper"
 	"solo/pkg/logger"
 	"solo/pkg/models"
@@ -17,8 +19,11 @@ import (
 	"solo/pkg/utils/stype"
 
 	"solo/services/chat/repo"
+	"solo/services/game/moderation"
+	"solo/services/user/repository"
 
 	"github.com/gorilla/websocket"
+	"github.com/samber/lo"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -27,6 +32,9 @@ type MQEmitter interface {
 	PublishChatMessageEvent(event eventtypes.ChatEvent) error
 	PublishFinalChoiceTimeoutEvent(event eventtypes.FinalChoiceTimeoutEvent) error
 	PublishRoomTimeoutEvent(timeoutEvent eventtypes.RoomTimeoutEvent) error
+	PublishRoomRemainTimeEvent(event eventtypes.RoomRemainTimeEvent) error
+	PublishCoupleRoomCloseEvent(event eventtypes.CoupleRoomCloseEvent) error
+	PublishChatMediaDeleteEvent(event eventtypes.ChatMediaDeleteEvent) error
 }
 
 // Client 구조체 - WebSocket 클라이언트
@@ -34,22 +42,89 @@ type Client struct {
 	Conn *websocket.Conn
 	Send chan interface{}
 	Ctx  context.Context
+
+	mu     sync.RWMutex
+	closed bool // Send 채널이 닫혔는지 여부
+}
+
+// Deliver - Send 채널에 메시지 전달 (이미 닫힌 클라이언트나 취소된 경우 false)
+func (c *Client) Deliver(ctx context.Context, message interface{}) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.closed {
+		return false
+	}
+
+	select {
+	case c.Send <- message:
+		return true
+	case <-c.Ctx.Done():
+		return false
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// TrySend - 블로킹 없이 Send 채널에 메시지 전달 (닫혔거나 버퍼가 가득 차면 false)
+func (c *Client) TrySend(message interface{}) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.closed {
+		return false
+	}
+
+	select {
+	case c.Send <- message:
+		return true
+	default:
+		return false
+	}
+}
+
+// Close - Send 채널을 한 번만 닫음
+func (c *Client) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return
+	}
+	c.closed = true
+	close(c.Send)
 }
 
 // GameService - 게임 서비스 계층
 type GameService struct {
-	redisClient *redis.RedisClient
-	chatRepo    *repo.ChatRepository
-	clients     sync.Map // key: userID, value: *Client
-	emitter     MQEmitter
+	redisClient      *redis.RedisClient
+	chatRepo         *repo.ChatRepository
+	userRepo         *repository.UserRepository
+	clients          sync.Map // key: userID, value: *Client
+	emitter          MQEmitter
+	moderator        *moderation.Pipeline
+	activities       *ActivityRegistry
+	draining         atomic.Bool // 종료 준비 중 여부
+	roomExtend       roomExtendConfig
+	extendVoteTimers sync.Map // key: voteID, value: *time.Timer (연장 투표 마감 타이머)
+	whisperLimits    whisperLimits
+	coupleExtend     coupleExtendConfig
+	serverID         string // 활성 사용자 등록에 쓰는 파드 고유 ID
 }
 
 // NewGameService - GameService 인스턴스 생성
-func NewGameService(redisClient *redis.RedisClient, emitter MQEmitter, chatRepo *repo.ChatRepository) *GameService {
+func NewGameService(redisClient *redis.RedisClient, emitter MQEmitter, chatRepo *repo.ChatRepository, userRepo *repository.UserRepository, moderator *moderation.Pipeline) *GameService {
 	service := &GameService{
-		redisClient: redisClient,
-		chatRepo:    chatRepo,
-		emitter:     emitter,
+		redisClient:   redisClient,
+		chatRepo:      chatRepo,
+		userRepo:      userRepo,
+		emitter:       emitter,
+		moderator:     moderator,
+		activities:    NewActivityRegistry(),
+		roomExtend:    loadRoomExtendConfig(),
+		whisperLimits: loadWhisperLimits(),
+		coupleExtend:  loadCoupleExtendConfig(),
+		serverID:      loadServerID(),
 	}
 
 	// 게임방 대화 시간 타임아웃 모니터링
@@ -58,23 +133,36 @@ func NewGameService(redisClient *redis.RedisClient, emitter MQEmitter, chatRepo
 	// 최종 선택 시간 타임아웃 모니터링
 	go service.MonitorFinalChoiceTimeouts()
 
-	// 밸런스 게임 타이머 모니터링
-	go service.MonitorBalanceGameStartTimer()
+	// 커플 채팅방 만료 모니터링
+	go service.MonitorCoupleRoomTimeouts()
+
+	// 방 활동 등록
+	service.RegisterActivity(NewBalanceGameActivity(service))
+	service.RegisterActivity(NewFirstImpressionActivity(service))
+
+	// 이전 방식으로 예약된 밸런스 게임을 활동 스케줄로 옮긴 후 모니터링 시작
+	service.MigrateLegacyBalanceGameTimers()
 
-	// 밸런스 게임 종료 모니터링
-	go service.MonitorBalanceGameFinishTimer()
+	// 예약된 방 활동 (밸런스 게임 등) 모니터링
+	go service.MonitorActivitySchedule()
 
 	return service
 }
 
+// loadServerID - 파드 이름(POD_NAME, HOSTNAME)으로 서버 고유 ID 결정
+func loadServerID() string {
+	serverID := config.GetString("POD_NAME", config.GetString("HOSTNAME", commontype.DEFAULT_TEMP_SERVER_ID))
+	log.Printf("🖥️ Game server ID: %s", serverID)
+	return serverID
+}
+
 // RegisterUserToGame - 사용자를 게임에 등록하고 Redis 활성화
 func (s *GameService) RegisterUserToGame(userID int, client *Client) error {
 	// WebSocket 클라이언트 저장
 	s.clients.Store(userID, client)
 
 	// Redis에 활성 사용자 등록
-	serverID := commontype.DEFAULT_TEMP_SERVER_ID // TODO: 서버 고유 ID 설정 필요
-	err := s.redisClient.RegisterActiveUser(userID, serverID)
+	err := s.redisClient.RegisterActiveUser(userID, s.serverID)
 	if err != nil {
 		log.Printf("❌ Redis 사용자 등록 실패: %v", err)
 		return err
@@ -89,12 +177,12 @@ func (s *GameService) UnRegisterUserFromGame(userID int) {
 	// WebSocket 클라이언트 제거
 	if clientInterface, ok := s.clients.Load(userID); ok {
 		client := clientInterface.(*Client)
-		close(client.Send) // Send 채널 닫기
+		client.Close() // Send 채널 닫기
 		s.clients.Delete(userID)
 	}
 
 	// Redis에서 활성 사용자 제거
-	err := s.redisClient.UnregisterActiveUser(userID)
+	err := s.redisClient.UnregisterActiveUser(userID, s.serverID)
 	if err != nil {
 		log.Printf("❌ Redis 사용자 제거 실패: %v", err)
 	} else {
@@ -102,9 +190,24 @@ func (s *GameService) UnRegisterUserFromGame(userID int) {
 	}
 }
 
-func (s *GameService) BroadcastMessage(roomID string, userID int, message string, headCnt int) error {
+func (s *GameService) BroadcastMessage(roomID string, userID int, message string, headCnt int, replyTo string) error {
 	log.Printf("💬 User %d sending message to room %s", userID, roomID)
 
+	// 답장 대상 확인 (같은 방의 공개 메시지만 가능)
+	replyToID, replyPreview, err := s.resolveReply(roomID, replyTo)
+	if err != nil {
+		return err
+	}
+
+	// 메시지 검열 (길이, 금칙어, 연락처)
+	message, ok, err := s.moderateMessage(roomID, userID, message)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return nil
+	}
+
 	// Redis에서 비활성 사용자 목록 조회
 	inactiveUserIDs, err := s.redisClient.GetInActiveUserIDs(roomID)
 	if err != nil {
@@ -127,6 +230,8 @@ func (s *GameService) BroadcastMessage(roomID string, userID int, message string
 		UnreadCount:     headCnt - len(joinedUserIDs),
 		InactiveUserIds: inactiveUserIDs,
 		ReaderIds:       joinedUserIDs,
+		ReplyTo:         replyToID,
+		ReplyPreview:    replyPreview,
 		CreatedAt:       time.Now(),
 	}
 
@@ -139,8 +244,116 @@ func (s *GameService) BroadcastMessage(roomID string, userID int, message string
 	return nil
 }
 
+// resolveReply - 답장 대상 메시지 검증 후 인용 미리보기 생성 (replyTo가 비어있으면 답장 아님)
+func (s *GameService) resolveReply(roomID, replyTo string) (primitive.ObjectID, *models.ReplyPreview, error) {
+	if replyTo == "" {
+		return primitive.NilObjectID, nil, nil
+	}
+
+	replyToID, err := primitive.ObjectIDFromHex(replyTo)
+	if err != nil {
+		return primitive.NilObjectID, nil, fmt.Errorf("❌ Invalid reply_to message ID: %s", replyTo)
+	}
+
+	quoted, err := s.chatRepo.GetChatByID(replyToID)
+	if err != nil {
+		return primitive.NilObjectID, nil, fmt.Errorf("❌ MongoDB GetChatByID 실패: %w", err)
+	}
+	if quoted == nil || quoted.RoomID != roomID {
+		return primitive.NilObjectID, nil, fmt.Errorf("❌ Reply target %s not found in room %s", replyTo, roomID)
+	}
+	// 귓속말을 공개 메시지로 인용하면 내용이 노출되므로 불가
+	if len(quoted.VisibleTo) > 0 {
+		return primitive.NilObjectID, nil, fmt.Errorf("❌ Cannot reply publicly to whisper %s", replyTo)
+	}
+	if quoted.DeletedAt != nil {
+		return primitive.NilObjectID, nil, fmt.Errorf("❌ Reply target %s was deleted", replyTo)
+	}
+
+	room, err := s.chatRepo.GetRoomByID(roomID)
+	if err != nil {
+		return primitive.NilObjectID, nil, fmt.Errorf("❌ GetRoomByID 실패: %w", err)
+	}
+	if room == nil {
+		return primitive.NilObjectID, nil, fmt.Errorf("❌ Room %s not found", roomID)
+	}
+
+	return replyToID, repo.BuildReplyPreview(replyToID, quoted, room), nil
+}
+
+// moderateMessage - 검열 파이프라인 실행, 마스킹/거절 시 발신자에게 사유 전달
+// 반환값: 최종 메시지, 전송 가능 여부
+func (s *GameService) moderateMessage(roomID string, userID int, message string) (string, bool, error) {
+	roomType, status, err := s.roomTypeAndStatus(roomID)
+	if err != nil {
+		return "", false, err
+	}
+
+	result := s.moderator.Run(moderation.Input{
+		RoomID:     roomID,
+		RoomType:   roomType,
+		RoomStatus: status,
+		SenderID:   userID,
+		Message:    message,
+	})
+
+	if result.Action == moderation.ActionAllow {
+		return result.Message, true, nil
+	}
+
+	log.Printf("🛡️ Message from user %d in room %s moderated: %s (%s)", userID, roomID, result.Action, result.Reason)
+
+	err = s.SendMessageToUser(userID, stype.WebSocketMessage{
+		Kind: stype.MessageKindModeration,
+		Payload: helper.ToJSON(stype.ModerationMessage{
+			RoomID: roomID,
+			Action: string(result.Action),
+			Stage:  result.Stage,
+			Reason: result.Reason,
+		}),
+	})
+	if err != nil {
+		log.Printf("❌ Failed to send moderation result to user %d: %v", userID, err)
+	}
+
+	return result.Message, result.Action != moderation.ActionReject, nil
+}
+
+// roomTypeAndStatus - 검열에 필요한 방 타입과 상태를 Redis에서 조회
+// 타입 캐시 도입 전에 생성된 방처럼 Redis에 없으면 MongoDB 사용
+func (s *GameService) roomTypeAndStatus(roomID string) (int, int, error) {
+	roomType, typeFound, err := s.redisClient.GetRoomType(roomID)
+	if err != nil {
+		log.Printf("⚠️ Redis GetRoomType 실패: %v", err)
+	}
+	status, statusErr := s.redisClient.GetRoomStatus(roomID)
+	if typeFound && statusErr == nil {
+		return roomType, status, nil
+	}
+
+	room, err := s.chatRepo.GetRoomByID(roomID)
+	if err != nil {
+		return 0, 0, fmt.Errorf("❌ GetRoomByID 실패: %w", err)
+	}
+	if room == nil {
+		return 0, 0, fmt.Errorf("❌ Room %s not found", roomID)
+	}
+
+	if !typeFound {
+		if err := s.redisClient.SetRoomType(roomID, room.Type); err != nil {
+			log.Printf("⚠️ Redis SetRoomType 실패: %v", err)
+		}
+	}
+
+	// Redis 상태가 없으면 MongoDB 상태 사용
+	if statusErr != nil {
+		status = room.Status
+	}
+	return room.Type, status, nil
+}
+
 func (s *GameService) SendMessageToRoom(roomID string, message stype.WebSocketMessage) error {
-	activeUserIDs, err := s.redisClient.GetActiveUserIDs(roomID)
+	activeUserIDs, err := s.redisClient.GetActiveUserIDs(roomID, s.serverID)
 	if err != nil {
 		log.Printf("❌ Redis GetActiveUserIDs 실패: %v", err)
 		return err
@@ -150,7 +363,27 @@ func (s *GameService) SendMessageToRoom(roomID string, message stype.WebSocketMe
 		if client, ok := s.clients.Load(userID); ok {
 			log.Printf("📨 Sending WebSocket %s message to User %d in Room %s", message.Kind, userID, roomID)
 
-			client.(*Client).Send <- message
+			s.sendToClient(userID, client.(*Client), message)
+		}
+	}
+
+	return nil
+}
+
+// SendTailoredMessageToRoom - 방의 활성 유저마다 개별 메시지 전송
+func (s *GameService) SendTailoredMessageToRoom(roomID string, build func(userID int) stype.WebSocketMessage) error {
+	activeUserIDs, err := s.redisClient.GetActiveUserIDs(roomID, s.serverID)
+	if err != nil {
+		log.Printf("❌ Redis GetActiveUserIDs 실패: %v", err)
+		return err
+	}
+
+	for _, userID := range activeUserIDs {
+		if client, ok := s.clients.Load(userID); ok {
+			message := build(userID)
+			log.Printf("📨 Sending WebSocket %s message to User %d in Room %s", message.Kind, userID, roomID)
+
+			s.sendToClient(userID, client.(*Client), message)
 		}
 	}
 
@@ -161,12 +394,23 @@ func (s *GameService) SendMessageToUser(userID int, message stype.WebSocketMessa
 	if client, ok := s.clients.Load(userID); ok {
 		log.Printf("📨 Sending WebSocket %s message to User %d", message.Kind, userID)
 
-		client.(*Client).Send <- message
+		s.sendToClient(userID, client.(*Client), message)
 	}
 
 	return nil
 }
 
+// sendToClient - 느린 클라이언트 때문에 호출자(MQ 워커 등)가 막히지 않도록 논블로킹 전송
+// 버퍼가 가득 찬 클라이언트는 연결을 끊어 재접속 후 resume으로 놓친 메시지를 받게 함
+func (s *GameService) sendToClient(userID int, client *Client, message stype.WebSocketMessage) {
+	if client.TrySend(message) {
+		return
+	}
+
+	log.Printf("⚠️ Send buffer of User %d is full or closed, dropping %s message and disconnecting", userID, message.Kind)
+	client.Conn.Close()
+}
+
 func (s *GameService) JoinGameRoom(roomID string, userID int) error {
 	log.Printf("🎮 User %d joining game room %s", userID, roomID)
 
@@ -281,6 +525,27 @@ func (s *GameService) ProcessRoomTimeoutMessage(roomTimeoutMsg stype.RoomTimeout
 }
 
 func (s *GameService) BroadcastFinalChoices(roomID string) error {
+	// 중복 타임아웃 이벤트로 결과가 두 번 공개되거나 저장된 결과가 덮어써지지 않도록 선점
+	claimed, err := s.redisClient.ClaimFinalChoiceBroadcast(roomID)
+	if err != nil {
+		return fmt.Errorf("❌ Redis ClaimFinalChoiceBroadcast 실패: %w", err)
+	}
+	if !claimed {
+		log.Printf("⚠️ Final choices of Room %s already broadcasted", roomID)
+		return nil
+	}
+
+	// 결과 저장 전에 실패하면 선점을 풀어 다음 타임아웃 이벤트에서 다시 공개
+	saved := false
+	defer func() {
+		if saved {
+			return
+		}
+		if err := s.redisClient.ReleaseFinalChoiceBroadcast(roomID); err != nil {
+			log.Printf("Failed to release final choice broadcast of room %s: %v", roomID, err)
+		}
+	}()
+
 	log.Printf("📢 Broadcasting final choices for Room %s", roomID)
 
 	// Redis에서 최종 선택 결과 조회
@@ -289,30 +554,26 @@ func (s *GameService) BroadcastFinalChoices(roomID string) error {
 		return fmt.Errorf("❌ Redis GetAllChoices 실패: %w", err)
 	}
 
-	// JSON 직렬화
-	payload, err := json.Marshal(finalChoiceResults)
+	chatRoom, err := s.chatRepo.GetRoomByID(roomID)
 	if err != nil {
-		return fmt.Errorf("❌ Final choices 직렬화 실패: %w", err)
+		return fmt.Errorf("❌ GetRoomByID 실패: %w", err)
 	}
-
-	// WebSocket 메시지 생성
-	message := stype.WebSocketMessage{
-		Kind:    stype.MessageKindFinalChoiceResult,
-		Payload: json.RawMessage(payload),
+	if chatRoom == nil {
+		return fmt.Errorf("❌ Room %s not found", roomID)
 	}
 
-	// 활성 유저에게 최종 선택 결과 전송
-	err = s.SendMessageToRoom(roomID, message)
+	// 공개 정책에 따라 수신자별로 결과를 다르게 전송
+	err = s.SendTailoredMessageToRoom(roomID, func(userID int) stype.WebSocketMessage {
+		return stype.WebSocketMessage{
+			Kind:    stype.MessageKindFinalChoiceResult,
+			Payload: helper.ToJSON(buildFinalChoiceResult(chatRoom.RevealPolicy, finalChoiceResults, userID)),
+		}
+	})
 	if err != nil {
 		return fmt.Errorf("❌ WebSocket 전송 실패: %w", err)
 	}
 
-	log.Printf("✅ Final choices broadcasted to Room %s", roomID)
-
-	chatRoom, err := s.chatRepo.GetRoomByID(roomID)
-	if err != nil {
-		return fmt.Errorf("❌ GetRoomByID 실패: %w", err)
-	}
+	log.Printf("✅ Final choices broadcasted to Room %s (reveal policy: %d)", roomID, chatRoom.RevealPolicy)
 
 	matchStrings := helper.ConvertUserChoicesToMatchStrings(finalChoiceResults.Choices)
 
@@ -321,6 +582,7 @@ func (s *GameService) BroadcastFinalChoices(roomID string) error {
 	if err != nil {
 		return fmt.Errorf("❌ UpdateFinalMatch 실패: %w", err)
 	}
+	saved = true
 
 	// Redis에서 최종 선택 정보 삭제
 	err = s.redisClient.ClearFinalChoiceRoom(roomID)
@@ -328,45 +590,192 @@ func (s *GameService) BroadcastFinalChoices(roomID string) error {
 		return fmt.Errorf("❌ Redis ClearFinalChoiceRoom 실패: %w", err)
 	}
 
+	// 결과 공개 이후에는 선택 변경 불가
+	err = s.redisClient.SetRoomStatus(roomID, commontype.RoomStatusChoiceComplete)
+	if err != nil {
+		return fmt.Errorf("❌ Redis SetRoomStatus(RoomStatusChoiceComplete) 실패: %w", err)
+	}
+
 	return nil
 }
 
+// buildFinalChoiceResult - 공개 정책에 맞춰 수신자 한 명에게 보낼 결과 생성
+func buildFinalChoiceResult(revealPolicy int, results *stype.FinalChoiceResultMessage, userID int) stype.FinalChoiceResultMessage {
+	message := stype.FinalChoiceResultMessage{
+		RoomID:       results.RoomID,
+		RevealPolicy: revealPolicy,
+	}
+
+	switch revealPolicy {
+	case commontype.RevealPolicyMutualOnly:
+		// 내가 선택한 상대도 나를 선택했는지만 공개
+		matched := false
+		for _, choice := range results.Choices {
+			if choice.UserID != userID {
+				continue
+			}
+			for _, other := range results.Choices {
+				if other.UserID == choice.SelectedUserID && other.SelectedUserID == userID {
+					matched = true
+					message.MatchedUserID = choice.SelectedUserID
+				}
+			}
+		}
+		message.Matched = &matched
+	case commontype.RevealPolicyAnonymousTally:
+		// 나를 선택한 인원 수만 공개
+		receivedCount := 0
+		for _, choice := range results.Choices {
+			if choice.SelectedUserID == userID {
+				receivedCount++
+			}
+		}
+		message.ReceivedCount = &receivedCount
+	default:
+		message.Choices = results.Choices
+	}
+
+	return message
+}
+
 func (s *GameService) ProcessFinalChoice(userID int, finalChoiceMsg stype.FinalChoiceMessage) error {
 	roomID := finalChoiceMsg.RoomID
 	selectedUserID := finalChoiceMsg.SelectedUserID
-	log.Printf("💘 User %d selected User %d in Room %s", userID, selectedUserID, roomID)
+	log.Printf("💘 User %d selected User %d in Room %s (withdraw: %t)", userID, selectedUserID, roomID, finalChoiceMsg.Withdraw)
+
+	ack := stype.FinalChoiceAckMessage{
+		RoomID:         roomID,
+		SelectedUserID: selectedUserID,
+		Withdrawn:      finalChoiceMsg.Withdraw,
+	}
+
+	reason, err := s.validateFinalChoice(userID, finalChoiceMsg)
+	if err != nil {
+		return err
+	}
+	if reason != "" {
+		log.Printf("⚠️ Final choice of user %d in room %s rejected: %s", userID, roomID, reason)
+		ack.Reason = reason
+		return s.sendFinalChoiceAck(userID, ack)
+	}
+
+	// 기존 선택 조회 (변경/철회 확인용)
+	previousSelectedUserID, hasPrevious, err := s.redisClient.GetUserChoice(roomID, userID)
+	if err != nil {
+		return fmt.Errorf("❌ Redis GetUserChoice 실패: %w", err)
+	}
+	if hasPrevious {
+		ack.PreviousSelectedUserID = previousSelectedUserID
+	}
+
+	if finalChoiceMsg.Withdraw {
+		if !hasPrevious {
+			ack.Reason = "철회할 선택이 없습니다"
+			return s.sendFinalChoiceAck(userID, ack)
+		}
+
+		err = s.redisClient.RemoveUserChoice(roomID, userID)
+		if err != nil {
+			return fmt.Errorf("❌ Redis RemoveUserChoice 실패: %w", err)
+		}
+
+		ack.Accepted = true
+		ack.SelectedUserID = 0
+		return s.sendFinalChoiceAck(userID, ack)
+	}
 
 	// 유저의 선택을 Redis에 저장
-	err := s.redisClient.SaveUserChoice(roomID, userID, selectedUserID)
+	err = s.redisClient.SaveUserChoice(roomID, userID, selectedUserID)
 	if err != nil {
 		return fmt.Errorf("❌ Redis SaveUserChoice 실패: %w", err)
 	}
 
-	// 방에 참여한 전체 유저 수 조회
-	roomTotalUserIDs, err := s.redisClient.GetRoomUserIDs(roomID)
+	ack.Accepted = true
+	err = s.sendFinalChoiceAck(userID, ack)
 	if err != nil {
-		return fmt.Errorf("❌ Redis GetRoomUserIDs 실패: %w", err)
+		log.Printf("❌ Failed to send final choice ack to user %d: %v", userID, err)
 	}
 
-	// 모든 유저가 선택을 완료했는지 확인
-	allChosen, err := s.redisClient.IsAllChoicesCompleted(roomID, int64(len(roomTotalUserIDs)))
+	// 마감 전까지 선택을 바꿀 수 있으므로 결과는 마감 시점(MonitorFinalChoiceTimeouts)에만 공개
+	return nil
+}
+
+// validateFinalChoice - 최종 선택 가능 여부 검증
+// 거절 사유가 있으면 reason으로 반환, 시스템 오류는 error로 반환
+func (s *GameService) validateFinalChoice(userID int, finalChoiceMsg stype.FinalChoiceMessage) (string, error) {
+	roomID := finalChoiceMsg.RoomID
+
+	status, err := s.redisClient.GetRoomStatus(roomID)
+	if err != nil || status != commontype.RoomStatusChoiceIng {
+		return "최종 선택 시간이 아닙니다", nil
+	}
+
+	room, err := s.chatRepo.GetRoomByID(roomID)
 	if err != nil {
-		return fmt.Errorf("❌ Redis IsAllChoicesCompleted 실패: %w", err)
+		return "", fmt.Errorf("❌ GetRoomByID 실패: %w", err)
+	}
+	if room == nil {
+		return "존재하지 않는 방입니다", nil
 	}
 
-	if allChosen {
-		log.Printf("🎉 All users in Room %s have made their final choices!", roomID)
+	if time.Now().After(room.FinishFinalChoiceAt) {
+		return "최종 선택 시간이 종료되었습니다", nil
+	}
 
-		// 최종 선택 결과 전송
-		err = s.BroadcastFinalChoices(roomID)
-		if err != nil {
-			return fmt.Errorf("❌ BroadcastFinalChoices 실패: %w", err)
-		}
+	chooser := findGamer(room, userID)
+	if chooser == nil {
+		return "방 참가자가 아닙니다", nil
+	}
+
+	if finalChoiceMsg.Withdraw {
+		return "", nil
 	}
 
+	if finalChoiceMsg.SelectedUserID == userID {
+		return "자기 자신은 선택할 수 없습니다", nil
+	}
+
+	target := findGamer(room, finalChoiceMsg.SelectedUserID)
+	if target == nil {
+		return "선택한 상대가 방에 없습니다", nil
+	}
+
+	// 성별 정보 도입 전에 생성된 방은 모두 0으로 저장되어 있어 검사 생략
+	if hasGenderData(room) && target.Gender == chooser.Gender {
+		return "이성만 선택할 수 있습니다", nil
+	}
+
+	return "", nil
+}
+
+func (s *GameService) sendFinalChoiceAck(userID int, ack stype.FinalChoiceAckMessage) error {
+	return s.SendMessageToUser(userID, stype.WebSocketMessage{
+		Kind:    stype.MessageKindFinalChoiceAck,
+		Payload: helper.ToJSON(ack),
+	})
+}
+
+// 방 참가자 중 특정 유저의 게임 정보 조회
+func findGamer(room *models.ChatRoom, userID int) *models.GamerInfo {
+	for i := range room.Gamers {
+		if room.Gamers[i].UserID == userID {
+			return &room.Gamers[i]
+		}
+	}
 	return nil
 }
 
+// hasGenderData - 방 참가자의 성별 정보가 저장되어 있는지 확인
+// 게임방은 항상 남녀가 함께 매칭되므로 모든 참가자의 성별이 같으면 성별 정보가 없는 방으로 판단
+func hasGenderData(room *models.ChatRoom) bool {
+	for _, gamer := range room.Gamers {
+		if gamer.Gender != room.Gamers[0].Gender {
+			return true
+		}
+	}
+	return false
+}
+
 // 채팅 시간 타임아웃 모니터링
 func (s *GameService) MonitorChatTimeouts() {
 	ticker := time.NewTicker(3 * time.Second) // 최대 1초 내에 이벤트 감지
@@ -383,8 +792,34 @@ func (s *GameService) MonitorChatTimeouts() {
 		for _, roomID := range rooms {
 			// 남은 시간이 0 이하인지 확인
 			remainingTime, err := s.redisClient.GetRoomRemainingTime(roomID)
-			if err != nil || remainingTime > 0 {
-				continue // 아직 만료되지 않은 방은 스킵
+			if err != nil {
+				continue
+			}
+			if remainingTime > 0 {
+				// 아직 만료되지 않은 방은 남은 시간 알림만 처리
+				s.notifyRoomRemaining(roomID, commontype.RoomPhaseChat, remainingTime, commontype.RoomRemainingMilestones)
+				continue
+			}
+
+			// 별도 타이머 도입 전에 생성된 커플 채팅방은 최종 선택 없이 종료
+			// 여러 파드가 같은 방을 중복 종료하지 않도록 방 목록에서 먼저 빼는 쪽만 진행
+			room, err := s.chatRepo.GetRoomByID(roomID)
+			if err == nil && room != nil && room.Type == commontype.MATCH_COUPLE {
+				claimed, err := s.redisClient.ClaimRoomClose(roomID)
+				if err != nil {
+					log.Printf("Failed to claim room %s close: %v", roomID, err)
+					continue
+				}
+				if !claimed {
+					continue
+				}
+
+				s.closeCoupleRoom(room)
+				err = s.redisClient.RemoveRoomFromRedis(roomID)
+				if err != nil {
+					log.Printf("Failed to remove expired room %s from Redis: %v", roomID, err)
+				}
+				continue
 			}
 
 			inactiveUsers, err := s.redisClient.GetInActiveUserIDs(roomID)
@@ -431,8 +866,13 @@ func (s *GameService) MonitorFinalChoiceTimeouts() {
 		for _, roomID := range rooms {
 			// 남은 시간이 0 이하인지 확인
 			remainingTime, err := s.redisClient.GetChoiceRoomRemainingTime(roomID)
-			if err != nil || remainingTime > 0 {
-				continue // 아직 만료되지 않은 방은 스킵
+			if err != nil {
+				continue
+			}
+			if remainingTime > 0 {
+				// 아직 만료되지 않은 방은 남은 시간 알림만 처리
+				s.notifyRoomRemaining(roomID, commontype.RoomPhaseFinalChoice, remainingTime, commontype.FinalChoiceRemainingMilestones)
+				continue
 			}
 
 			userIds, err := s.redisClient.GetRoomUserIDs(roomID)
@@ -468,192 +908,84 @@ func (s *GameService) MonitorFinalChoiceTimeouts() {
 	}
 }
 
-// 밸런스 게임 타이머 모니터링
-func (s *GameService) MonitorBalanceGameStartTimer() {
-	ticker := time.NewTicker(3 * time.Second)
-	defer ticker.Stop()
-
-	for range ticker.C {
-		// Redis에서 밸런스 게임 타이머가 설정된 모든 방 가져오기
-		rooms, err := s.redisClient.GetAllBalanceGameRooms()
-		if err != nil {
-			log.Printf("Failed to get balance game rooms: %v", err)
-			continue
-		}
-
-		for _, roomID := range rooms {
-			// 남은 시간 확인
-			remainingTime, err := s.redisClient.GetBalanceGameRemainingTime(roomID)
-			if err != nil || remainingTime > 0 {
-				continue
-			}
-
-			// 밸런스 게임 랜덤 획득
-			balanceGame, err := s.chatRepo.GetRandomBalanceGameForm()
-			if err != nil {
-				log.Printf("Failed to get random balance game form: %v", err)
-				continue
-			}
-
-			// 시간이 다 되었으면 밸런스 게임 시작 메시지 전송
-			balanceGameForm := &models.BalanceGameForm{
-				Question: models.Question{
-					Title: balanceGame.Title,
-					Red:   balanceGame.Red,
-					Blue:  balanceGame.Blue,
-				},
-				RoomID: roomID,
-			}
-
-			// 밸런스 게임 폼 저장
-			formID, err := s.chatRepo.InsertBalanceForm(balanceGameForm)
-			if err != nil {
-				log.Printf("Failed to insert balance game form: %v", err)
-				continue
-			}
-
-			// Redis에서 비활성 사용자 목록 조회
-			inactiveUserIDs, err := s.redisClient.GetInActiveUserIDs(roomID)
-			if err != nil {
-				log.Printf("Failed to GetInActiveUserIDs, room: %s, err: %v", roomID, err)
-				continue
-			}
-
-			// 방에 접속해있는 사용자 ID 리스트 가져오기
-			joinedUserIDs, err := s.redisClient.GetJoinedUser(roomID)
-			if err != nil {
-				log.Printf("Failed to GetJoinedUser, room: %s, err: %v", roomID, err)
-				continue
-			}
-
-			headCnt, err := s.redisClient.GetRoomUserIDs(roomID)
-			if err != nil {
-				log.Printf("Failed to GetRoomUserIDs, room: %s, err: %v", roomID, err)
-				continue
-			}
-
-			// 채팅 메시지 생성
-			chatEvent := eventtypes.ChatEvent{
-				MessageId:       primitive.NewObjectID(),
-				Type:            commontype.ChatTypeForm,
-				RoomID:          roomID,
-				SenderID:        commontype.MasterID,
-				Message:         "밸런스 게임을 시작합니다!",
-				BalanceFormID:   formID,
-				UnreadCount:     len(headCnt) - len(joinedUserIDs),
-				InactiveUserIds: inactiveUserIDs,
-				ReaderIds:       joinedUserIDs,
-				CreatedAt:       time.Now(),
-			}
-
-			// RabbitMQ를 통해 메시지 전송
-			err = s.emitter.PublishChatMessageEvent(chatEvent)
-			if err != nil {
-				log.Printf("Failed to publish balance game start message: %v", err)
-			}
-
-			// Redis에서 해당 방의 밸런스 게임 타이머 제거
-			err = s.redisClient.RemoveBalanceGameRoom(roomID)
-			if err != nil {
-				log.Printf("Failed to remove balance game timer: %v", err)
-			}
+// notifyRoomRemaining - 남은 시간이 알림 구간에 들어오면 방 전체에 한 번만 알림
+func (s *GameService) notifyRoomRemaining(roomID, phase string, remainingSeconds int, milestones []time.Duration) {
+	milestone := remainingMilestone(time.Duration(remainingSeconds)*time.Second, milestones)
+	if milestone == 0 {
+		return
+	}
 
-			// 밸런스 게임 종료 타이머 설정 (15분)
-			err = s.redisClient.SetBalanceGameFinishTimer(formID.Hex(), commontype.BalanceGameEndTimer)
-			if err != nil {
-				log.Printf("Failed to set balance game finish timer: %v", err)
-			}
+	claimed, err := s.redisClient.ClaimRoomRemainingMilestone(roomID, phase, milestone)
+	if err != nil || !claimed {
+		return
+	}
 
-			logger.Info(logger.LogEventBalanceGameStart, fmt.Sprintf("Balance game start: %s", roomID), chatEvent)
-		}
+	err = s.emitter.PublishRoomRemainTimeEvent(eventtypes.RoomRemainTimeEvent{
+		RoomID:           roomID,
+		Phase:            phase,
+		RemainingSeconds: remainingSeconds,
+	})
+	if err != nil {
+		log.Printf("Failed to publish room remain time event for RoomID %s: %v", roomID, err)
 	}
 }
 
-// 밸런스 게임 종료 모니터링
-func (s *GameService) MonitorBalanceGameFinishTimer() {
-	ticker := time.NewTicker(3 * time.Second)
-	defer ticker.Stop()
-
-	for range ticker.C {
-		// Redis에서 밸런스 게임 종료 타이머가 설정된 모든 form 가져오기
-		forms, err := s.redisClient.GetAllBalanceGameFinishForms()
-		if err != nil {
-			log.Printf("Failed to get balance game finish forms: %v", err)
-			continue
+// remainingMilestone - 남은 시간을 포함하는 가장 작은 알림 구간 (예: 8분 남음 → 10분 구간), 없으면 0
+func remainingMilestone(remaining time.Duration, milestones []time.Duration) time.Duration {
+	var milestone time.Duration
+	for _, m := range milestones {
+		if remaining <= m && (milestone == 0 || m < milestone) {
+			milestone = m
 		}
+	}
+	return milestone
+}
 
-		for _, formID := range forms {
-			// 남은 시간 확인
-			remainingTime, err := s.redisClient.GetBalanceGameFinishRemainingTime(formID)
-			if err != nil || remainingTime > 0 {
-				continue
-			}
-
-			// form ID를 ObjectID로 변환
-			formObjectID, err := primitive.ObjectIDFromHex(formID)
-			if err != nil {
-				log.Printf("Failed to convert form ID to ObjectID: %v", err)
-				continue
-			}
-
-			// form 정보 조회
-			form, err := s.chatRepo.GetBalanceFormByID(formObjectID)
-			if err != nil {
-				log.Printf("Failed to get balance form: %v", err)
-				continue
-			}
-
-			roomID := form.RoomID
-
-			// Redis에서 비활성 사용자 목록 조회
-			inactiveUserIDs, err := s.redisClient.GetInActiveUserIDs(roomID)
-			if err != nil {
-				log.Printf("Failed to GetInActiveUserIDs, room: %s, err: %v", roomID, err)
-				continue
-			}
-
-			// 방에 접속해있는 사용자 ID 리스트 가져오기
-			joinedUserIDs, err := s.redisClient.GetJoinedUser(roomID)
-			if err != nil {
-				log.Printf("Failed to GetJoinedUser, room: %s, err: %v", roomID, err)
-				continue
-			}
-
-			headCnt, err := s.redisClient.GetRoomUserIDs(roomID)
-			if err != nil {
-				log.Printf("Failed to GetRoomUserIDs, room: %s, err: %v", roomID, err)
-				continue
-			}
-
-			// 채팅 메시지 생성
-			chatEvent := eventtypes.ChatEvent{
-				MessageId:       primitive.NewObjectID(),
-				Type:            commontype.ChatTypeFormResult,
-				RoomID:          roomID,
-				SenderID:        commontype.MasterID,
-				Message:         "밸런스 게임이 종료되었습니다!",
-				BalanceFormID:   form.ID,
-				UnreadCount:     len(headCnt) - len(joinedUserIDs),
-				InactiveUserIds: inactiveUserIDs,
-				ReaderIds:       joinedUserIDs,
-				CreatedAt:       time.Now(),
-			}
-
-			// RabbitMQ를 통해 메시지 전송
-			err = s.emitter.PublishChatMessageEvent(chatEvent)
-			if err != nil {
-				log.Printf("Failed to publish balance game finish message: %v", err)
-			}
+// SendRoomRemaining - 요청한 사용자에게 서버 기준 남은 시간 전송
+func (s *GameService) SendRoomRemaining(userID int, roomID string) error {
+	roomUserIDs, err := s.redisClient.GetRoomUserIDs(roomID)
+	if err != nil {
+		return fmt.Errorf("❌ Redis GetRoomUserIDs 실패: %w", err)
+	}
+	if !lo.Contains(roomUserIDs, fmt.Sprintf("%d", userID)) {
+		return fmt.Errorf("❌ User %d is not a member of room %s", userID, roomID)
+	}
 
-			log.Printf("🎮 Balance game in room %s has finished! Form ID: %s", form.RoomID, formID)
+	message, err := s.roomRemaining(roomID)
+	if err != nil {
+		return err
+	}
 
-			// Redis에서 해당 form의 밸런스 게임 종료 타이머 제거
-			err = s.redisClient.RemoveBalanceGameFinishForm(formID)
-			if err != nil {
-				log.Printf("Failed to remove balance game finish timer: %v", err)
-			}
+	return s.SendMessageToUser(userID, stype.WebSocketMessage{
+		Kind:    stype.MessageKindRoomRemaining,
+		Payload: helper.ToJSON(message),
+	})
+}
 
-			logger.Info(logger.LogEventBalanceGameEnd, fmt.Sprintf("Balance game end: %s", roomID), chatEvent)
+// roomRemaining - 방의 현재 단계와 남은 시간 조회
+func (s *GameService) roomRemaining(roomID string) (stype.RoomRemainingMessage, error) {
+	status, err := s.redisClient.GetRoomStatus(roomID)
+	if err != nil {
+		return stype.RoomRemainingMessage{}, fmt.Errorf("❌ Redis GetRoomStatus 실패: %w", err)
+	}
+
+	message := stype.RoomRemainingMessage{RoomID: roomID, Phase: commontype.RoomPhaseChat}
+	switch status {
+	case commontype.RoomStatusChoiceIng:
+		message.Phase = commontype.RoomPhaseFinalChoice
+		message.RemainingSeconds, err = s.redisClient.GetChoiceRoomRemainingTime(roomID)
+	case commontype.RoomStatusGameStart, commontype.RoomStatusGameIng:
+		// 커플 채팅방은 별도 타이머 사용
+		message.RemainingSeconds, err = s.redisClient.GetCoupleRoomRemainingTime(roomID)
+		if err == nil && message.RemainingSeconds > 0 {
+			message.Phase = commontype.RoomPhaseCouple
+			break
 		}
+		message.RemainingSeconds, err = s.redisClient.GetRoomRemainingTime(roomID)
+	}
+	if err != nil {
+		return message, fmt.Errorf("❌ Redis 남은 시간 조회 실패: %w", err)
 	}
+
+	return message, nil
 }
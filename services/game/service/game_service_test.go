@@ -0,0 +1,137 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"solo/pkg/models"
+	"solo/pkg/types/commontype"
+	"solo/pkg/utils/stype"
+)
+
+func TestFindGamer(t *testing.T) {
+	room := &models.ChatRoom{Gamers: []models.GamerInfo{
+		{UserID: 1, Gender: commontype.MALE},
+		{UserID: 2, Gender: commontype.FEMALE},
+	}}
+
+	gamer := findGamer(room, 2)
+	if gamer == nil {
+		t.Fatal("findGamer(2) = nil, want participant")
+	}
+	if gamer.Gender != commontype.FEMALE {
+		t.Errorf("gender = %d, want %d", gamer.Gender, commontype.FEMALE)
+	}
+	// 방 정보의 참가자를 그대로 가리켜야 함
+	if gamer != &room.Gamers[1] {
+		t.Error("findGamer returned a copy instead of the room's gamer")
+	}
+
+	if gamer := findGamer(room, 3); gamer != nil {
+		t.Errorf("findGamer(3) = %+v, want nil", gamer)
+	}
+}
+
+func TestHasGenderData(t *testing.T) {
+	if hasGenderData(&models.ChatRoom{}) {
+		t.Error("room without gamers has gender data")
+	}
+
+	// 성별 도입 전에 생성된 방은 모두 0으로 저장되어 있음
+	legacy := &models.ChatRoom{Gamers: []models.GamerInfo{{UserID: 1}, {UserID: 2}, {UserID: 3}}}
+	if hasGenderData(legacy) {
+		t.Error("legacy room with zero genders has gender data")
+	}
+
+	mixed := &models.ChatRoom{Gamers: []models.GamerInfo{
+		{UserID: 1, Gender: commontype.MALE},
+		{UserID: 2, Gender: commontype.MALE},
+		{UserID: 3, Gender: commontype.FEMALE},
+	}}
+	if !hasGenderData(mixed) {
+		t.Error("mixed room has no gender data")
+	}
+}
+
+func TestBuildFinalChoiceResult(t *testing.T) {
+	// 1 ↔ 2 서로 선택, 3 → 2, 4 → 1
+	results := &stype.FinalChoiceResultMessage{
+		RoomID: "room",
+		Choices: []stype.UserChoice{
+			{UserID: 1, SelectedUserID: 2},
+			{UserID: 2, SelectedUserID: 1},
+			{UserID: 3, SelectedUserID: 2},
+			{UserID: 4, SelectedUserID: 1},
+		},
+	}
+
+	t.Run("전체 공개", func(t *testing.T) {
+		got := buildFinalChoiceResult(commontype.RevealPolicyFull, results, 3)
+		if len(got.Choices) != len(results.Choices) {
+			t.Fatalf("choices = %+v, want all %d choices", got.Choices, len(results.Choices))
+		}
+		if got.Matched != nil || got.ReceivedCount != nil {
+			t.Errorf("full reveal leaked per-user fields: matched=%v received=%v", got.Matched, got.ReceivedCount)
+		}
+	})
+
+	t.Run("서로 선택한 경우만 공개", func(t *testing.T) {
+		got := buildFinalChoiceResult(commontype.RevealPolicyMutualOnly, results, 1)
+		if got.Matched == nil || !*got.Matched || got.MatchedUserID != 2 {
+			t.Errorf("user 1: matched=%v matchedUserID=%d, want matched with 2", got.Matched, got.MatchedUserID)
+		}
+		if got.Choices != nil {
+			t.Errorf("mutual only revealed choices: %+v", got.Choices)
+		}
+
+		// 3은 2를 선택했지만 2는 1을 선택
+		got = buildFinalChoiceResult(commontype.RevealPolicyMutualOnly, results, 3)
+		if got.Matched == nil || *got.Matched || got.MatchedUserID != 0 {
+			t.Errorf("user 3: matched=%v matchedUserID=%d, want unmatched", got.Matched, got.MatchedUserID)
+		}
+	})
+
+	t.Run("받은 선택 수만 공개", func(t *testing.T) {
+		want := map[int]int{1: 2, 2: 2, 3: 0, 4: 0}
+		for userID, count := range want {
+			got := buildFinalChoiceResult(commontype.RevealPolicyAnonymousTally, results, userID)
+			if got.ReceivedCount == nil || *got.ReceivedCount != count {
+				t.Errorf("user %d: received = %v, want %d", userID, got.ReceivedCount, count)
+			}
+			if got.Choices != nil || got.Matched != nil {
+				t.Errorf("user %d: anonymous tally revealed choices or match", userID)
+			}
+		}
+	})
+
+	if results.RoomID != "room" || len(results.Choices) != 4 {
+		t.Error("buildFinalChoiceResult modified the shared results")
+	}
+}
+
+func TestRemainingMilestone(t *testing.T) {
+	milestones := commontype.RoomRemainingMilestones // 30분, 10분, 5분, 1분
+
+	tests := []struct {
+		remaining time.Duration
+		want      time.Duration
+	}{
+		{45 * time.Minute, 0},
+		{30 * time.Minute, 30 * time.Minute},
+		{8 * time.Minute, 10 * time.Minute},
+		{5 * time.Minute, 5 * time.Minute},
+		{10 * time.Second, time.Minute},
+	}
+
+	for _, tt := range tests {
+		if got := remainingMilestone(tt.remaining, milestones); got != tt.want {
+			t.Errorf("remainingMilestone(%v) = %v, want %v", tt.remaining, got, tt.want)
+		}
+	}
+
+	// 구간 순서와 상관없이 가장 작은 구간 선택
+	shuffled := []time.Duration{time.Minute, 30 * time.Minute, 5 * time.Minute}
+	if got := remainingMilestone(3*time.Minute, shuffled); got != 5*time.Minute {
+		t.Errorf("remainingMilestone(3m, unordered) = %v, want 5m", got)
+	}
+}
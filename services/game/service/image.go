@@ -0,0 +1,122 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"solo/pkg/helper"
+	"solo/pkg/media"
+	"solo/pkg/models"
+	"solo/pkg/types/commontype"
+	eventtypes "solo/pkg/types/eventtype"
+	"solo/pkg/utils/stype"
+	"solo/services/game/moderation"
+
+	"github.com/samber/lo"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// SendImage - 채팅 서비스에 업로드된 이미지를 이미지 메시지로 전송
+func (s *GameService) SendImage(userID int, msg stype.ImageMessage) error {
+	mediaID, err := primitive.ObjectIDFromHex(msg.MediaID)
+	if err != nil {
+		return fmt.Errorf("❌ Invalid media ID: %s", msg.MediaID)
+	}
+
+	room, err := s.chatRepo.GetRoomByID(msg.RoomID)
+	if err != nil {
+		return fmt.Errorf("❌ MongoDB GetRoomByID 실패: %w", err)
+	}
+	if room == nil {
+		return fmt.Errorf("❌ Room not found: %s", msg.RoomID)
+	}
+	if !lo.Contains(room.UserIDs, userID) {
+		return fmt.Errorf("❌ User %d is not a member of room %s", userID, msg.RoomID)
+	}
+
+	chatMedia, err := s.chatRepo.GetChatMediaByID(mediaID)
+	if err != nil {
+		return fmt.Errorf("❌ MongoDB GetChatMediaByID 실패: %w", err)
+	}
+	if chatMedia == nil || chatMedia.RoomID != msg.RoomID || chatMedia.UploaderID != userID {
+		return fmt.Errorf("❌ Media %s not uploaded by user %d in room %s", msg.MediaID, userID, msg.RoomID)
+	}
+
+	// 업로드 이후 구간이 바뀌었을 수 있으므로 전송 시점에 다시 확인
+	status, err := s.redisClient.GetRoomStatus(msg.RoomID)
+	if err != nil {
+		status = room.Status
+	}
+	if !media.ImageAllowedInRoom(room.Type, status) {
+		return s.SendMessageToUser(userID, stype.WebSocketMessage{
+			Kind: stype.MessageKindModeration,
+			Payload: helper.ToJSON(stype.ModerationMessage{
+				RoomID: msg.RoomID,
+				Action: string(moderation.ActionReject),
+				Stage:  "media",
+				Reason: "지금은 사진을 보낼 수 없습니다",
+			}),
+		})
+	}
+
+	inactiveUserIDs, err := s.redisClient.GetInActiveUserIDs(msg.RoomID)
+	if err != nil {
+		return fmt.Errorf("❌ Redis GetInActiveUserIDs 실패: %w", err)
+	}
+
+	joinedUserIDs, err := s.redisClient.GetJoinedUser(msg.RoomID)
+	if err != nil {
+		return fmt.Errorf("❌ Redis GetJoinedUser 실패: %w", err)
+	}
+
+	// 같은 미디어를 여러 메시지로 보내면 한 메시지를 취소할 때 다른 메시지의 사진까지 지워지므로 한 번만 허용
+	messageID := primitive.NewObjectID()
+	bound, err := s.chatRepo.BindChatMediaToMessage(chatMedia.ID, messageID)
+	if err != nil {
+		return fmt.Errorf("❌ MongoDB BindChatMediaToMessage 실패: %w", err)
+	}
+	if !bound {
+		return s.SendMessageToUser(userID, stype.WebSocketMessage{
+			Kind: stype.MessageKindModeration,
+			Payload: helper.ToJSON(stype.ModerationMessage{
+				RoomID: msg.RoomID,
+				Action: string(moderation.ActionReject),
+				Stage:  "media",
+				Reason: "이미 보낸 사진입니다",
+			}),
+		})
+	}
+
+	chatEvent := eventtypes.ChatEvent{
+		MessageId:       messageID,
+		Type:            commontype.ChatTypeImage,
+		RoomID:          msg.RoomID,
+		SenderID:        userID,
+		Message:         "사진",
+		UnreadCount:     len(room.UserIDs) - len(joinedUserIDs),
+		InactiveUserIds: inactiveUserIDs,
+		ReaderIds:       joinedUserIDs,
+		Media: &models.MediaRef{
+			MediaID:      chatMedia.ID,
+			ContentType:  chatMedia.ContentType,
+			Width:        chatMedia.Width,
+			Height:       chatMedia.Height,
+			URL:          media.URL(msg.RoomID, chatMedia.ID.Hex(), false),
+			ThumbnailURL: media.URL(msg.RoomID, chatMedia.ID.Hex(), true),
+		},
+		CreatedAt: time.Now(),
+	}
+
+	err = s.emitter.PublishChatMessageEvent(chatEvent)
+	if err != nil {
+		// 메시지가 만들어지지 않았으므로 다시 보낼 수 있도록 연결 해제
+		if unbindErr := s.chatRepo.UnbindChatMedia(chatMedia.ID, messageID); unbindErr != nil {
+			log.Printf("Failed to unbind media %s: %v", msg.MediaID, unbindErr)
+		}
+		return fmt.Errorf("❌ RabbitMQ PublishChatMessageEvent 실패: %w", err)
+	}
+
+	log.Printf("🖼️ User %d sent image %s to room %s", userID, msg.MediaID, msg.RoomID)
+	return nil
+}
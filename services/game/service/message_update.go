@@ -0,0 +1,167 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"solo/pkg/config"
+	"solo/pkg/helper"
+	"solo/pkg/models"
+	"solo/pkg/types/commontype"
+	eventtypes "solo/pkg/types/eventtype"
+	"solo/pkg/utils/stype"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// EditMessage - 발신자가 수정 가능 시간 안에 보낸 메시지 수정
+func (s *GameService) EditMessage(userID int, msg stype.MessageEditMessage) error {
+	chat, reason, err := s.validateMessageUpdate(userID, msg.RoomID, msg.MessageID)
+	if err != nil {
+		return err
+	}
+	if reason == "" && chat.Type != commontype.ChatTypeChat && chat.Type != commontype.ChatTypeWhisper {
+		reason = "텍스트 메시지만 수정할 수 있습니다"
+	}
+	if reason != "" {
+		return s.sendMessageUpdateAck(userID, msg.RoomID, msg.MessageID, reason)
+	}
+
+	// 메시지 검열 (길이, 금칙어, 연락처)
+	message, ok, err := s.moderateMessage(msg.RoomID, userID, msg.Message)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return nil
+	}
+
+	edited, err := s.chatRepo.EditChatMessage(chat.MessageId, userID, message, time.Now().Add(-s.messageEditWindow()))
+	if err != nil {
+		return fmt.Errorf("❌ MongoDB EditChatMessage 실패: %w", err)
+	}
+	if edited == nil {
+		return s.sendMessageUpdateAck(userID, msg.RoomID, msg.MessageID, "수정할 수 없는 메시지입니다")
+	}
+
+	log.Printf("✏️ User %d edited message %s in room %s", userID, msg.MessageID, msg.RoomID)
+	return s.broadcastMessageUpdate(userID, edited, "edit")
+}
+
+// DeleteMessage - 발신자가 수정 가능 시간 안에 보낸 메시지 보내기 취소
+func (s *GameService) DeleteMessage(userID int, msg stype.MessageDeleteMessage) error {
+	chat, reason, err := s.validateMessageUpdate(userID, msg.RoomID, msg.MessageID)
+	if err != nil {
+		return err
+	}
+	if reason != "" {
+		return s.sendMessageUpdateAck(userID, msg.RoomID, msg.MessageID, reason)
+	}
+
+	deleted, err := s.chatRepo.DeleteChatMessage(chat.MessageId, userID, time.Now().Add(-s.messageEditWindow()))
+	if err != nil {
+		return fmt.Errorf("❌ MongoDB DeleteChatMessage 실패: %w", err)
+	}
+	if deleted == nil {
+		return s.sendMessageUpdateAck(userID, msg.RoomID, msg.MessageID, "보내기 취소할 수 없는 메시지입니다")
+	}
+
+	// 툼스톤에는 리액션을 남기지 않음
+	err = s.chatRepo.DeleteReactionsByMessageID(deleted.MessageId)
+	if err != nil {
+		log.Printf("Failed to delete reactions of message %s: %v", msg.MessageID, err)
+	}
+
+	// 이미지 객체와 기록은 저장소를 가진 채팅 서비스에서 삭제
+	if chat.Media != nil {
+		err = s.emitter.PublishChatMediaDeleteEvent(eventtypes.ChatMediaDeleteEvent{
+			RoomID:    chat.RoomID,
+			MediaID:   chat.Media.MediaID.Hex(),
+			MessageID: chat.MessageId.Hex(),
+		})
+		if err != nil {
+			log.Printf("Failed to publish media delete of message %s: %v", msg.MessageID, err)
+		}
+	}
+
+	log.Printf("🗑️ User %d deleted message %s in room %s", userID, msg.MessageID, msg.RoomID)
+	return s.broadcastMessageUpdate(userID, deleted, "delete")
+}
+
+// validateMessageUpdate - 수정/삭제 가능 여부 확인, 불가하면 사유 반환
+func (s *GameService) validateMessageUpdate(userID int, roomID, messageID string) (*models.Chat, string, error) {
+	objectID, err := primitive.ObjectIDFromHex(messageID)
+	if err != nil {
+		return nil, "", fmt.Errorf("❌ Invalid message ID: %s", messageID)
+	}
+
+	chat, err := s.chatRepo.GetChatByID(objectID)
+	if err != nil {
+		return nil, "", fmt.Errorf("❌ MongoDB GetChatByID 실패: %w", err)
+	}
+	if chat == nil || chat.RoomID != roomID {
+		return nil, "", fmt.Errorf("❌ Message %s not found in room %s", messageID, roomID)
+	}
+
+	switch {
+	case chat.SenderID != userID:
+		return nil, "본인이 보낸 메시지만 수정하거나 취소할 수 있습니다", nil
+	case chat.DeletedAt != nil:
+		return nil, "이미 취소된 메시지입니다", nil
+	case time.Since(chat.CreatedAt) > s.messageEditWindow():
+		return nil, fmt.Sprintf("보낸 지 %d분이 지난 메시지는 수정하거나 취소할 수 없습니다", int(s.messageEditWindow().Minutes())), nil
+	}
+
+	return chat, "", nil
+}
+
+// broadcastMessageUpdate - 메시지를 볼 수 있는 사용자에게 변경 내용 전송 후 요청자에게 결과 전송
+func (s *GameService) broadcastMessageUpdate(userID int, chat *models.Chat, action string) error {
+	message := stype.WebSocketMessage{
+		Kind: stype.MessageKindMessageUpdated,
+		Payload: helper.ToJSON(stype.MessageUpdatedMessage{
+			RoomID:    chat.RoomID,
+			MessageID: chat.MessageId.Hex(),
+			Action:    action,
+			Message:   chat.Message,
+			EditedAt:  chat.EditedAt,
+			DeletedAt: chat.DeletedAt,
+		}),
+	}
+
+	var err error
+	if len(chat.VisibleTo) > 0 {
+		err = s.SendMessageToUsers(chat.VisibleTo, message)
+	} else {
+		err = s.SendMessageToRoom(chat.RoomID, message)
+	}
+	if err != nil {
+		return err
+	}
+
+	return s.SendMessageToUser(userID, stype.WebSocketMessage{
+		Kind: stype.MessageKindMessageUpdateAck,
+		Payload: helper.ToJSON(stype.MessageUpdateAckMessage{
+			RoomID:    chat.RoomID,
+			MessageID: chat.MessageId.Hex(),
+			Accepted:  true,
+		}),
+	})
+}
+
+func (s *GameService) sendMessageUpdateAck(userID int, roomID, messageID, reason string) error {
+	return s.SendMessageToUser(userID, stype.WebSocketMessage{
+		Kind: stype.MessageKindMessageUpdateAck,
+		Payload: helper.ToJSON(stype.MessageUpdateAckMessage{
+			RoomID:    roomID,
+			MessageID: messageID,
+			Accepted:  false,
+			Reason:    reason,
+		}),
+	})
+}
+
+func (s *GameService) messageEditWindow() time.Duration {
+	return config.GetDuration("MESSAGE_EDIT_WINDOW", commontype.MessageEditWindow)
+}
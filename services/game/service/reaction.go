@@ -0,0 +1,93 @@
+package service
+
+import (
+	"fmt"
+	"log"
+
+	"solo/pkg/helper"
+	"solo/pkg/models"
+	"solo/pkg/types/commontype"
+	"solo/pkg/utils/stype"
+
+	"github.com/samber/lo"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// HandleReaction - 메시지 리액션 추가/제거 후 변경분을 방에 전송
+func (s *GameService) HandleReaction(userID int, msg stype.ReactionMessage) error {
+	if !lo.Contains(commontype.ReactionTypes, msg.Reaction) {
+		return fmt.Errorf("❌ Unknown reaction: %s", msg.Reaction)
+	}
+
+	messageID, err := primitive.ObjectIDFromHex(msg.MessageID)
+	if err != nil {
+		return fmt.Errorf("❌ Invalid message ID: %s", msg.MessageID)
+	}
+
+	roomUserIDs, err := s.redisClient.GetRoomUserIDs(msg.RoomID)
+	if err != nil {
+		return fmt.Errorf("❌ Redis GetRoomUserIDs 실패: %w", err)
+	}
+	if !lo.Contains(roomUserIDs, fmt.Sprintf("%d", userID)) {
+		return fmt.Errorf("❌ User %d is not a member of room %s", userID, msg.RoomID)
+	}
+
+	chat, err := s.chatRepo.GetChatByID(messageID)
+	if err != nil {
+		return fmt.Errorf("❌ MongoDB GetChatByID 실패: %w", err)
+	}
+	if chat == nil || chat.RoomID != msg.RoomID {
+		return fmt.Errorf("❌ Message %s not found in room %s", msg.MessageID, msg.RoomID)
+	}
+	if chat.DeletedAt != nil {
+		return fmt.Errorf("❌ Message %s was deleted", msg.MessageID)
+	}
+	// 다른 사람의 귓속말에는 리액션 불가
+	if len(chat.VisibleTo) > 0 && !lo.Contains(chat.VisibleTo, userID) {
+		return fmt.Errorf("❌ Message %s is not visible to user %d", msg.MessageID, userID)
+	}
+
+	action := "add"
+	var changed bool
+	if msg.Remove {
+		action = "remove"
+		changed, err = s.chatRepo.RemoveReaction(messageID, userID, msg.Reaction)
+	} else {
+		changed, err = s.chatRepo.AddReaction(models.MessageReaction{
+			MessageID: messageID,
+			RoomID:    msg.RoomID,
+			UserID:    userID,
+			Reaction:  msg.Reaction,
+		})
+	}
+	if err != nil {
+		return fmt.Errorf("❌ MongoDB reaction %s 실패: %w", action, err)
+	}
+	if !changed {
+		return nil
+	}
+
+	count, err := s.chatRepo.CountReaction(messageID, msg.Reaction)
+	if err != nil {
+		return fmt.Errorf("❌ MongoDB CountReaction 실패: %w", err)
+	}
+
+	log.Printf("👍 User %d %s reaction %s on message %s", userID, action, msg.Reaction, msg.MessageID)
+
+	message := stype.WebSocketMessage{
+		Kind: stype.MessageKindReaction,
+		Payload: helper.ToJSON(stype.ReactionDeltaMessage{
+			RoomID:    msg.RoomID,
+			MessageID: msg.MessageID,
+			UserID:    userID,
+			Reaction:  msg.Reaction,
+			Action:    action,
+			Count:     count,
+		}),
+	}
+
+	if len(chat.VisibleTo) > 0 {
+		return s.SendMessageToUsers(chat.VisibleTo, message)
+	}
+	return s.SendMessageToRoom(msg.RoomID, message)
+}
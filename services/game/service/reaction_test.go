@@ -0,0 +1,34 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"solo/pkg/types/commontype"
+	"solo/pkg/utils/stype"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 잘못된 요청은 Redis, MongoDB 조회 전에 거절
+func TestHandleReactionRejectsInvalidInput(t *testing.T) {
+	s := &GameService{}
+
+	err := s.HandleReaction(1, stype.ReactionMessage{
+		RoomID:    "room",
+		MessageID: primitive.NewObjectID().Hex(),
+		Reaction:  "🍕",
+	})
+	if err == nil || !strings.Contains(err.Error(), "Unknown reaction") {
+		t.Errorf("unknown reaction error = %v", err)
+	}
+
+	err = s.HandleReaction(1, stype.ReactionMessage{
+		RoomID:    "room",
+		MessageID: "not-an-object-id",
+		Reaction:  commontype.ReactionTypes[0],
+	})
+	if err == nil || !strings.Contains(err.Error(), "Invalid message ID") {
+		t.Errorf("invalid message ID error = %v", err)
+	}
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"fmt"
+	"log"
+
+	"solo/pkg/helper"
+	"solo/pkg/models"
+	"solo/pkg/types/commontype"
+	"solo/pkg/utils/stype"
+
+	"github.com/samber/lo"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Resume - 앱 복귀 후 새 소켓에서 놓친 메시지와 현재 단계를 한 번에 응답
+// 화면에 열려 있는 방(foreground) 하나만 재참여해 읽음 처리, 나머지 방은 안 읽은 상태로 유지
+func (s *GameService) Resume(userID int, msg stype.ResumeMessage) error {
+	rooms := normalizeResumeRooms(msg.Rooms)
+
+	result := stype.ResumeResultMessage{Rooms: make([]stype.ResumeRoomResult, 0, len(rooms))}
+	for _, resumeRoom := range rooms {
+		roomResult, err := s.resumeRoom(userID, resumeRoom)
+		if err != nil {
+			log.Printf("❌ Resume room %s for user %d 실패: %v", resumeRoom.RoomID, userID, err)
+			roomResult = stype.ResumeRoomResult{RoomID: resumeRoom.RoomID, Error: "방을 복구할 수 없습니다"}
+		}
+		result.Rooms = append(result.Rooms, roomResult)
+	}
+
+	return s.SendMessageToUser(userID, stype.WebSocketMessage{
+		Kind:    stype.MessageKindResumeResult,
+		Payload: helper.ToJSON(result),
+	})
+}
+
+// normalizeResumeRooms - 중복 방 제거, 최대 방 수 제한, foreground 방이 여러 개 오면 첫 번째 방만 인정
+func normalizeResumeRooms(requested []stype.ResumeRoom) []stype.ResumeRoom {
+	rooms := lo.UniqBy(requested, func(r stype.ResumeRoom) string { return r.RoomID })
+	if len(rooms) > commontype.ResumeMaxRooms {
+		rooms = rooms[:commontype.ResumeMaxRooms]
+	}
+
+	foregroundFound := false
+	for i := range rooms {
+		if rooms[i].Foreground && foregroundFound {
+			rooms[i].Foreground = false
+		}
+		foregroundFound = foregroundFound || rooms[i].Foreground
+	}
+
+	return rooms
+}
+
+func (s *GameService) resumeRoom(userID int, resumeRoom stype.ResumeRoom) (stype.ResumeRoomResult, error) {
+	result := stype.ResumeRoomResult{RoomID: resumeRoom.RoomID, Messages: []*models.Chat{}}
+
+	room, err := s.chatRepo.GetRoomByID(resumeRoom.RoomID)
+	if err != nil {
+		return result, fmt.Errorf("❌ MongoDB GetRoomByID 실패: %w", err)
+	}
+	if room == nil {
+		return result, fmt.Errorf("❌ Room not found: %s", resumeRoom.RoomID)
+	}
+	if !lo.Contains(room.UserIDs, userID) {
+		return result, fmt.Errorf("❌ User %d is not a member of room %s", userID, resumeRoom.RoomID)
+	}
+
+	var lastSeenID primitive.ObjectID
+	if resumeRoom.LastSeenMessageID != "" {
+		lastSeenID, err = primitive.ObjectIDFromHex(resumeRoom.LastSeenMessageID)
+		if err != nil {
+			return result, fmt.Errorf("❌ Invalid last seen message ID %s: %w", resumeRoom.LastSeenMessageID, err)
+		}
+	}
+
+	// 화면에 열려 있는 방만 재참여 (room.join 이벤트로 채팅 서비스의 HandleRoomJoin에서 읽음 처리)
+	if resumeRoom.Foreground {
+		err = s.JoinGameRoom(room.ID, userID)
+		if err != nil {
+			return result, err
+		}
+	}
+
+	messages, hasMore, err := s.chatRepo.GetMessagesAfter(room.ID, userID, lastSeenID, commontype.ResumeMaxMessages)
+	if err != nil {
+		return result, fmt.Errorf("❌ MongoDB GetMessagesAfter 실패: %w", err)
+	}
+
+	// 답장 미리보기와 리액션은 실패해도 메시지는 전달
+	if err := s.chatRepo.AttachReplyPreviews(messages, room); err != nil {
+		log.Printf("Failed to attach reply previews for room %s: %v", room.ID, err)
+	}
+	if err := s.chatRepo.AttachReactions(messages, userID); err != nil {
+		log.Printf("Failed to attach reactions for room %s: %v", room.ID, err)
+	}
+
+	if messages != nil {
+		result.Messages = messages
+	}
+	result.HasMore = hasMore
+
+	remaining, err := s.roomRemaining(room.ID)
+	if err != nil {
+		log.Printf("Failed to get room remaining for room %s: %v", room.ID, err)
+	} else {
+		result.Phase = remaining.Phase
+		result.RemainingSeconds = remaining.RemainingSeconds
+	}
+
+	return result, nil
+}
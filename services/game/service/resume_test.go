@@ -0,0 +1,52 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+
+	"solo/pkg/types/commontype"
+	"solo/pkg/utils/stype"
+)
+
+func TestNormalizeResumeRooms(t *testing.T) {
+	requested := []stype.ResumeRoom{
+		{RoomID: "a"},
+		{RoomID: "b", Foreground: true},
+		{RoomID: "a", Foreground: true}, // 중복 방은 처음 요청만 사용
+		{RoomID: "c", Foreground: true}, // 두 번째 foreground
+	}
+
+	rooms := normalizeResumeRooms(requested)
+
+	if len(rooms) != 3 {
+		t.Fatalf("rooms = %+v, want a, b, c", rooms)
+	}
+	var foreground []string
+	for _, room := range rooms {
+		if room.Foreground {
+			foreground = append(foreground, room.RoomID)
+		}
+	}
+	if len(foreground) != 1 || foreground[0] != "b" {
+		t.Errorf("foreground rooms = %v, want only b", foreground)
+	}
+	// 요청 원본은 그대로 유지
+	if !requested[3].Foreground {
+		t.Error("normalizeResumeRooms modified the request")
+	}
+}
+
+func TestNormalizeResumeRoomsLimit(t *testing.T) {
+	var requested []stype.ResumeRoom
+	for i := 0; i < commontype.ResumeMaxRooms+5; i++ {
+		requested = append(requested, stype.ResumeRoom{RoomID: fmt.Sprintf("room-%d", i)})
+	}
+
+	rooms := normalizeResumeRooms(requested)
+	if len(rooms) != commontype.ResumeMaxRooms {
+		t.Fatalf("len(rooms) = %d, want %d", len(rooms), commontype.ResumeMaxRooms)
+	}
+	if rooms[0].RoomID != "room-0" {
+		t.Errorf("first room = %s, want the first requested room", rooms[0].RoomID)
+	}
+}
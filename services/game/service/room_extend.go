@@ -0,0 +1,304 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"solo/pkg/config"
+	"solo/pkg/helper"
+	"solo/pkg/logger"
+	"solo/pkg/types/commontype"
+	"solo/pkg/utils/stype"
+	"solo/services/user/repository"
+
+	"github.com/samber/lo"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// roomExtendConfig - 방 대화 시간 연장 설정
+type roomExtendConfig struct {
+	window        time.Duration
+	duration      time.Duration
+	voteTimeout   time.Duration
+	quorumPercent int
+	maxCount      int
+	pointCost     int
+}
+
+func loadRoomExtendConfig() roomExtendConfig {
+	return roomExtendConfig{
+		window:        config.GetDuration("ROOM_EXTEND_WINDOW", commontype.RoomExtendWindow),
+		duration:      config.GetDuration("ROOM_EXTEND_DURATION", commontype.RoomExtendDuration),
+		voteTimeout:   config.GetDuration("ROOM_EXTEND_VOTE_TIMEOUT", commontype.RoomExtendVoteTimer),
+		quorumPercent: config.GetInt("ROOM_EXTEND_QUORUM_PERCENT", commontype.RoomExtendQuorumPercent),
+		maxCount:      config.GetInt("ROOM_EXTEND_MAX_COUNT", commontype.RoomExtendMaxCount),
+		pointCost:     config.GetInt("ROOM_EXTEND_POINT_COST", commontype.RoomExtendPointCost),
+	}
+}
+
+// required - 방 인원 대비 필요한 찬성 수
+func (c roomExtendConfig) required(headCnt int) int {
+	required := (headCnt*c.quorumPercent + 99) / 100
+	return lo.Clamp(required, 1, headCnt)
+}
+
+// ProposeRoomExtension - 대화 시간 연장 투표 시작
+func (s *GameService) ProposeRoomExtension(userID int, msg stype.RoomExtendProposeMessage) error {
+	roomID := msg.RoomID
+
+	roomUserIDs, err := s.redisClient.GetRoomUserIDs(roomID)
+	if err != nil {
+		return fmt.Errorf("❌ Redis GetRoomUserIDs 실패: %w", err)
+	}
+	if !lo.Contains(roomUserIDs, fmt.Sprintf("%d", userID)) {
+		return fmt.Errorf("❌ User %d is not a member of room %s", userID, roomID)
+	}
+
+	reason, err := s.validateRoomExtendProposal(userID, roomID)
+	if err != nil {
+		return err
+	}
+	if reason != "" {
+		return s.SendMessageToUser(userID, stype.WebSocketMessage{
+			Kind:    stype.MessageKindRoomExtendResult,
+			Payload: helper.ToJSON(stype.RoomExtendResultMessage{RoomID: roomID, Extended: false, Reason: reason}),
+		})
+	}
+
+	voteID := primitive.NewObjectID().Hex()
+	started, err := s.redisClient.StartRoomExtendVote(roomID, voteID, userID, s.roomExtend.voteTimeout)
+	if err != nil {
+		return fmt.Errorf("❌ Redis StartRoomExtendVote 실패: %w", err)
+	}
+	if !started {
+		return s.SendMessageToUser(userID, stype.WebSocketMessage{
+			Kind:    stype.MessageKindRoomExtendResult,
+			Payload: helper.ToJSON(stype.RoomExtendResultMessage{RoomID: roomID, Extended: false, Reason: "이미 진행 중인 연장 투표가 있습니다"}),
+		})
+	}
+
+	log.Printf("⏰ User %d proposed extension of room %s", userID, roomID)
+
+	// 투표 시간이 끝나면 정족수 미달로 종료 (먼저 결론이 나면 finishRoomExtendVote에서 타이머 해제)
+	timer := time.AfterFunc(s.roomExtend.voteTimeout, func() {
+		s.finishRoomExtendVote(roomID, voteID, false, "투표 시간이 종료되었습니다")
+	})
+	s.extendVoteTimers.Store(voteID, timer)
+
+	return s.evaluateRoomExtendVote(roomID)
+}
+
+// validateRoomExtendProposal - 연장 제안 가능 여부 확인, 불가하면 사유 반환
+func (s *GameService) validateRoomExtendProposal(userID int, roomID string) (string, error) {
+	status, err := s.redisClient.GetRoomStatus(roomID)
+	if err != nil {
+		return "", fmt.Errorf("❌ Redis GetRoomStatus 실패: %w", err)
+	}
+	if status != commontype.RoomStatusGameIng {
+		return "대화 중인 방만 연장할 수 있습니다", nil
+	}
+
+	room, err := s.chatRepo.GetRoomByID(roomID)
+	if err != nil {
+		return "", fmt.Errorf("❌ MongoDB GetRoomByID 실패: %w", err)
+	}
+	if room == nil {
+		return "", fmt.Errorf("❌ Room not found: %s", roomID)
+	}
+	if room.Type != commontype.MATCH_GAME {
+		return "게임방만 연장할 수 있습니다", nil
+	}
+	if room.ExtendCount >= s.roomExtend.maxCount {
+		return "더 이상 연장할 수 없습니다", nil
+	}
+
+	remainingTime, err := s.redisClient.GetRoomRemainingTime(roomID)
+	if err != nil {
+		return "", fmt.Errorf("❌ Redis GetRoomRemainingTime 실패: %w", err)
+	}
+	if remainingTime <= 0 || time.Duration(remainingTime)*time.Second > s.roomExtend.window {
+		return fmt.Sprintf("종료 %d분 전부터 연장을 제안할 수 있습니다", int(s.roomExtend.window.Minutes())), nil
+	}
+
+	if s.roomExtend.pointCost > 0 {
+		user, err := s.userRepo.GetUserByID(userID)
+		if err != nil {
+			return "", fmt.Errorf("❌ MySQL GetUserByID 실패: %w", err)
+		}
+		if user.GamePoint < s.roomExtend.pointCost {
+			return fmt.Sprintf("연장에는 %d 포인트가 필요합니다", s.roomExtend.pointCost), nil
+		}
+	}
+
+	return "", nil
+}
+
+// VoteRoomExtension - 연장 투표 찬반 처리
+func (s *GameService) VoteRoomExtension(userID int, msg stype.RoomExtendVoteMessage) error {
+	roomUserIDs, err := s.redisClient.GetRoomUserIDs(msg.RoomID)
+	if err != nil {
+		return fmt.Errorf("❌ Redis GetRoomUserIDs 실패: %w", err)
+	}
+	if !lo.Contains(roomUserIDs, fmt.Sprintf("%d", userID)) {
+		return fmt.Errorf("❌ User %d is not a member of room %s", userID, msg.RoomID)
+	}
+
+	voted, err := s.redisClient.SetRoomExtendVote(msg.RoomID, userID, msg.Agree)
+	if err != nil {
+		return fmt.Errorf("❌ Redis SetRoomExtendVote 실패: %w", err)
+	}
+	if !voted {
+		return fmt.Errorf("❌ No extend vote in progress for room %s", msg.RoomID)
+	}
+
+	return s.evaluateRoomExtendVote(msg.RoomID)
+}
+
+// evaluateRoomExtendVote - 정족수 충족/불가 여부 판단 후 진행 현황 전송
+func (s *GameService) evaluateRoomExtendVote(roomID string) error {
+	vote, err := s.redisClient.GetRoomExtendVote(roomID)
+	if err != nil {
+		return fmt.Errorf("❌ Redis GetRoomExtendVote 실패: %w", err)
+	}
+	if vote == nil {
+		return nil
+	}
+
+	roomUserIDs, err := s.redisClient.GetRoomUserIDs(roomID)
+	if err != nil {
+		return fmt.Errorf("❌ Redis GetRoomUserIDs 실패: %w", err)
+	}
+
+	headCnt := len(roomUserIDs)
+	required := s.roomExtend.required(headCnt)
+	agreeCount := lo.CountBy(lo.Values(vote.Votes), func(agree bool) bool { return agree })
+	denyCount := len(vote.Votes) - agreeCount
+
+	switch {
+	case agreeCount >= required:
+		s.finishRoomExtendVote(roomID, vote.VoteID, true, "")
+		return nil
+	case denyCount > headCnt-required:
+		s.finishRoomExtendVote(roomID, vote.VoteID, false, "반대가 많아 연장되지 않았습니다")
+		return nil
+	}
+
+	return s.SendMessageToRoom(roomID, stype.WebSocketMessage{
+		Kind: stype.MessageKindRoomExtendVote,
+		Payload: helper.ToJSON(stype.RoomExtendVoteStatusMessage{
+			RoomID:     roomID,
+			ProposerID: vote.ProposerID,
+			AgreeCount: agreeCount,
+			DenyCount:  denyCount,
+			Required:   required,
+			PointCost:  s.roomExtend.pointCost,
+			ExpiresAt:  vote.ExpiresAt,
+		}),
+	})
+}
+
+// finishRoomExtendVote - 투표 종료 후 연장 적용 및 결과 전송 (같은 투표는 여러 번 호출되어도 한 번만 처리)
+func (s *GameService) finishRoomExtendVote(roomID, voteID string, agreed bool, reason string) {
+	if timer, ok := s.extendVoteTimers.LoadAndDelete(voteID); ok {
+		timer.(*time.Timer).Stop()
+	}
+
+	vote, err := s.redisClient.GetRoomExtendVote(roomID)
+	if err != nil {
+		log.Printf("Failed to get extend vote for room %s: %v", roomID, err)
+		return
+	}
+	if vote == nil || vote.VoteID != voteID {
+		return
+	}
+
+	cleared, err := s.redisClient.ClearRoomExtendVote(roomID, voteID)
+	if err != nil || !cleared {
+		return
+	}
+
+	result := stype.RoomExtendResultMessage{RoomID: roomID, Extended: false, Reason: reason}
+	if agreed {
+		result, err = s.applyRoomExtension(roomID, vote.ProposerID)
+		if err != nil {
+			log.Printf("Failed to extend room %s: %v", roomID, err)
+			result = stype.RoomExtendResultMessage{RoomID: roomID, Extended: false, Reason: "연장에 실패했습니다"}
+		}
+	}
+
+	err = s.SendMessageToRoom(roomID, stype.WebSocketMessage{
+		Kind:    stype.MessageKindRoomExtendResult,
+		Payload: helper.ToJSON(result),
+	})
+	if err != nil {
+		log.Printf("Failed to send extend result to room %s: %v", roomID, err)
+	}
+}
+
+// applyRoomExtension - MongoDB 종료 시각과 Redis 타임아웃 연장
+func (s *GameService) applyRoomExtension(roomID string, proposerID int) (stype.RoomExtendResultMessage, error) {
+	result := stype.RoomExtendResultMessage{RoomID: roomID}
+
+	// 투표 중 대화 시간이 끝났으면 연장하지 않음
+	status, err := s.redisClient.GetRoomStatus(roomID)
+	if err != nil {
+		return result, fmt.Errorf("❌ Redis GetRoomStatus 실패: %w", err)
+	}
+	if status != commontype.RoomStatusGameIng {
+		result.Reason = "대화 시간이 이미 종료되었습니다"
+		return result, nil
+	}
+
+	room, err := s.chatRepo.GetRoomByID(roomID)
+	if err != nil {
+		return result, fmt.Errorf("❌ MongoDB GetRoomByID 실패: %w", err)
+	}
+	if room == nil {
+		return result, fmt.Errorf("❌ Room not found: %s", roomID)
+	}
+
+	if s.roomExtend.pointCost > 0 {
+		err = s.userRepo.SpendGamePoint(proposerID, s.roomExtend.pointCost)
+		if errors.Is(err, repository.ErrNotEnoughGamePoint) {
+			log.Printf("⚠️ User %d has not enough point to extend room %s", proposerID, roomID)
+			result.Reason = "제안자의 포인트가 부족합니다"
+			return result, nil
+		}
+		if err != nil {
+			return result, fmt.Errorf("❌ MySQL SpendGamePoint 실패: %w", err)
+		}
+
+		// 연장 적용에 실패하면 차감한 포인트 환불
+		defer func() {
+			if result.Extended {
+				return
+			}
+			if err := s.userRepo.RefundGamePoint(proposerID, s.roomExtend.pointCost); err != nil {
+				log.Printf("❌ Failed to refund %d point to user %d for room %s: %v", s.roomExtend.pointCost, proposerID, roomID, err)
+			}
+		}()
+	}
+
+	finishChatAt := room.FinishChatAt.Add(s.roomExtend.duration)
+	finishFinalChoiceAt := finishChatAt.Add(commontype.FinishFinalChoiceTimer)
+
+	err = s.chatRepo.ExtendRoomTime(roomID, finishChatAt, finishFinalChoiceAt)
+	if err != nil {
+		return result, fmt.Errorf("❌ MongoDB ExtendRoomTime 실패: %w", err)
+	}
+
+	err = s.redisClient.SetRoomTimeout(roomID, time.Until(finishChatAt))
+	if err != nil {
+		return result, fmt.Errorf("❌ Redis SetRoomTimeout 실패: %w", err)
+	}
+
+	result.Extended = true
+	result.FinishChatAt = finishChatAt
+	result.FinishFinalChoiceAt = finishFinalChoiceAt
+	result.RemainingSeconds = int(time.Until(finishChatAt).Seconds())
+
+	logger.Info(logger.LogEventGameRoomExtend, fmt.Sprintf("Game room extended: %s", roomID), result)
+	return result, nil
+}
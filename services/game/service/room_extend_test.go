@@ -0,0 +1,26 @@
+package service
+
+import "testing"
+
+func TestRoomExtendRequired(t *testing.T) {
+	// 정원 비율은 올림, 최소 1명, 최대 방 인원
+	cfg := roomExtendConfig{quorumPercent: 50}
+	for headCnt, want := range map[int]int{1: 1, 2: 1, 3: 2, 4: 2, 5: 3, 8: 4} {
+		if got := cfg.required(headCnt); got != want {
+			t.Errorf("required(%d) at 50%% = %d, want %d", headCnt, got, want)
+		}
+	}
+
+	unanimous := roomExtendConfig{quorumPercent: 100}
+	if got := unanimous.required(6); got != 6 {
+		t.Errorf("required(6) at 100%% = %d, want 6", got)
+	}
+
+	// 잘못 설정된 비율도 방 인원 범위 안으로 제한
+	if got := (roomExtendConfig{quorumPercent: 0}).required(4); got != 1 {
+		t.Errorf("required(4) at 0%% = %d, want 1", got)
+	}
+	if got := (roomExtendConfig{quorumPercent: 150}).required(4); got != 4 {
+		t.Errorf("required(4) at 150%% = %d, want 4", got)
+	}
+}
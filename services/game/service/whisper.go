@@ -0,0 +1,161 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"solo/pkg/config"
+	"solo/pkg/helper"
+	"solo/pkg/types/commontype"
+	eventtypes "solo/pkg/types/eventtype"
+	"solo/pkg/utils/stype"
+
+	"github.com/samber/lo"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// whisperLimits - 구간별 귓속말 허용 횟수
+type whisperLimits map[string]int
+
+func loadWhisperLimits() whisperLimits {
+	return whisperLimits{
+		commontype.RoomPhaseChat:        config.GetInt("WHISPER_LIMIT_CHAT", commontype.WhisperLimitChat),
+		commontype.RoomPhaseFinalChoice: config.GetInt("WHISPER_LIMIT_FINAL_CHOICE", commontype.WhisperLimitFinalChoice),
+	}
+}
+
+// SendWhisper - 같은 방의 한 참가자에게만 보이는 귓속말 전송
+func (s *GameService) SendWhisper(userID int, msg stype.WhisperMessage) error {
+	phase, reason, err := s.validateWhisper(userID, msg)
+	if err != nil {
+		return err
+	}
+	if reason != "" {
+		return s.sendWhisperAck(userID, stype.WhisperAckMessage{RoomID: msg.RoomID, Accepted: false, Reason: reason})
+	}
+
+	// 메시지 검열 (길이, 금칙어, 연락처)
+	message, ok, err := s.moderateMessage(msg.RoomID, userID, msg.Message)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return nil
+	}
+
+	// 구간별 사용 횟수 확인
+	limit := s.whisperLimits[phase]
+	count, err := s.redisClient.IncrWhisperCount(msg.RoomID, phase, userID)
+	if err != nil {
+		return fmt.Errorf("❌ Redis IncrWhisperCount 실패: %w", err)
+	}
+	if count > limit {
+		if err := s.redisClient.DecrWhisperCount(msg.RoomID, phase, userID); err != nil {
+			log.Printf("❌ Redis DecrWhisperCount 실패: %v", err)
+		}
+		return s.sendWhisperAck(userID, stype.WhisperAckMessage{
+			RoomID:   msg.RoomID,
+			Accepted: false,
+			Reason:   fmt.Sprintf("이번 단계에서는 귓속말을 %d번까지 보낼 수 있습니다", limit),
+		})
+	}
+
+	joinedUserIDs, err := s.redisClient.GetJoinedUser(msg.RoomID)
+	if err != nil {
+		return fmt.Errorf("❌ Redis GetJoinedUser 실패: %w", err)
+	}
+
+	inactiveUserIDs, err := s.redisClient.GetInActiveUserIDs(msg.RoomID)
+	if err != nil {
+		return fmt.Errorf("❌ Redis GetInActiveUserIDs 실패: %w", err)
+	}
+
+	// 수신자 기준으로 읽음/푸시 대상 계산
+	unreadCount := 1
+	var readerIDs []int
+	if lo.Contains(joinedUserIDs, msg.RecipientID) {
+		unreadCount = 0
+		readerIDs = []int{msg.RecipientID}
+	}
+
+	chatEvent := eventtypes.ChatEvent{
+		MessageId:       primitive.NewObjectID(),
+		Type:            commontype.ChatTypeWhisper,
+		RoomID:          msg.RoomID,
+		SenderID:        userID,
+		Message:         message,
+		UnreadCount:     unreadCount,
+		InactiveUserIds: lo.Intersect(inactiveUserIDs, []int{msg.RecipientID}),
+		ReaderIds:       readerIDs,
+		RecipientID:     msg.RecipientID,
+		VisibleTo:       []int{userID, msg.RecipientID},
+		CreatedAt:       time.Now(),
+	}
+
+	err = s.emitter.PublishChatMessageEvent(chatEvent)
+	if err != nil {
+		if err := s.redisClient.DecrWhisperCount(msg.RoomID, phase, userID); err != nil {
+			log.Printf("❌ Redis DecrWhisperCount 실패: %v", err)
+		}
+		return fmt.Errorf("❌ RabbitMQ PublishChatMessageEvent 실패: %w", err)
+	}
+
+	log.Printf("🤫 Whisper from %d to %d in room %s", userID, msg.RecipientID, msg.RoomID)
+	return s.sendWhisperAck(userID, stype.WhisperAckMessage{RoomID: msg.RoomID, Accepted: true, Remaining: limit - count})
+}
+
+// validateWhisper - 귓속말 가능 여부 확인 후 현재 구간 반환, 불가하면 사유 반환
+func (s *GameService) validateWhisper(userID int, msg stype.WhisperMessage) (string, string, error) {
+	room, err := s.chatRepo.GetRoomByID(msg.RoomID)
+	if err != nil {
+		return "", "", fmt.Errorf("❌ MongoDB GetRoomByID 실패: %w", err)
+	}
+	if room == nil {
+		return "", "", fmt.Errorf("❌ Room not found: %s", msg.RoomID)
+	}
+	if findGamer(room, userID) == nil {
+		return "", "", fmt.Errorf("❌ User %d is not a member of room %s", userID, msg.RoomID)
+	}
+
+	if room.Type != commontype.MATCH_GAME {
+		return "", "게임방에서만 귓속말을 보낼 수 있습니다", nil
+	}
+	if msg.RecipientID == userID {
+		return "", "자기 자신에게는 귓속말을 보낼 수 없습니다", nil
+	}
+	if findGamer(room, msg.RecipientID) == nil {
+		return "", "같은 방의 참가자에게만 귓속말을 보낼 수 있습니다", nil
+	}
+
+	status, err := s.redisClient.GetRoomStatus(msg.RoomID)
+	if err != nil {
+		return "", "", fmt.Errorf("❌ Redis GetRoomStatus 실패: %w", err)
+	}
+
+	switch status {
+	case commontype.RoomStatusGameStart, commontype.RoomStatusGameIng:
+		return commontype.RoomPhaseChat, "", nil
+	case commontype.RoomStatusChoiceIng:
+		return commontype.RoomPhaseFinalChoice, "", nil
+	default:
+		return "", "지금은 귓속말을 보낼 수 없습니다", nil
+	}
+}
+
+func (s *GameService) sendWhisperAck(userID int, ack stype.WhisperAckMessage) error {
+	return s.SendMessageToUser(userID, stype.WebSocketMessage{
+		Kind:    stype.MessageKindWhisperAck,
+		Payload: helper.ToJSON(ack),
+	})
+}
+
+// SendMessageToUsers - 지정한 사용자에게만 메시지 전송 (귓속말 등)
+func (s *GameService) SendMessageToUsers(userIDs []int, message stype.WebSocketMessage) error {
+	for _, userID := range lo.Uniq(userIDs) {
+		if err := s.SendMessageToUser(userID, message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
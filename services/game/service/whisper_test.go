@@ -0,0 +1,31 @@
+package service
+
+import (
+	"testing"
+
+	"solo/pkg/types/commontype"
+)
+
+func TestLoadWhisperLimits(t *testing.T) {
+	t.Setenv("WHISPER_LIMIT_CHAT", "")
+	t.Setenv("WHISPER_LIMIT_FINAL_CHOICE", "")
+
+	limits := loadWhisperLimits()
+	if limits[commontype.RoomPhaseChat] != commontype.WhisperLimitChat {
+		t.Errorf("chat limit = %d, want default %d", limits[commontype.RoomPhaseChat], commontype.WhisperLimitChat)
+	}
+	if limits[commontype.RoomPhaseFinalChoice] != commontype.WhisperLimitFinalChoice {
+		t.Errorf("final choice limit = %d, want default %d", limits[commontype.RoomPhaseFinalChoice], commontype.WhisperLimitFinalChoice)
+	}
+
+	t.Setenv("WHISPER_LIMIT_FINAL_CHOICE", "0")
+	limits = loadWhisperLimits()
+	if limits[commontype.RoomPhaseFinalChoice] != 0 {
+		t.Errorf("final choice limit = %d, want 0 from env", limits[commontype.RoomPhaseFinalChoice])
+	}
+
+	// 귓속말을 보낼 수 없는 구간은 허용 횟수 0
+	if limits[commontype.RoomPhaseCouple] != 0 {
+		t.Errorf("couple limit = %d, want 0", limits[commontype.RoomPhaseCouple])
+	}
+}
@@ -26,15 +26,17 @@ func (c *Consumer) StartListening() {
 	}
 
 	// Queue 생성 및 바인딩
-	queue, err := c.mqClient.DeclareQueue(mq.QueuePush, mq.ExchangeAppTopic, []string{mq.RoutingKeyChat, mq.RoutingKeyRoomTimeout})
+	queue, err := c.mqClient.DeclareQueue(mq.QueuePush, mq.ExchangeAppTopic, []string{mq.RoutingKeyChat, mq.RoutingKeyRoomTimeout, mq.RoutingKeyRoomRemainTime, mq.RoutingKeyCoupleRoomClose})
 	if err != nil {
 		log.Fatalf("❌ Failed to declare queue %s for %s: %v", mq.QueuePush, mq.ExchangeAppTopic, err)
 	}
 
 	// 이벤트 핸들러 등록
 	handlers := mq.EventHandlerMap{
-		mq.EventTypeChat:        c.eventHandler.HandleChatEvent,
-		mq.EventTypeRoomTimeout: c.eventHandler.HandleRoomTimeoutEvent,
+		mq.EventTypeChat:            c.eventHandler.HandleChatEvent,
+		mq.EventTypeRoomTimeout:     c.eventHandler.HandleRoomTimeoutEvent,
+		mq.EventTypeRoomRemainTime:  c.eventHandler.HandleRoomRemainTimeEvent,
+		mq.EventTypeCoupleRoomClose: c.eventHandler.HandleCoupleRoomCloseEvent,
 	}
 
 	// 메시지 소비 시작
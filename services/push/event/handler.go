@@ -8,6 +8,7 @@ import (
 	eventtypes "solo/pkg/types/eventtype"
 	"solo/services/push/onesignal"
 	"solo/services/user/service"
+	"time"
 
 	"github.com/samber/lo"
 )
@@ -98,6 +99,80 @@ func (h *EventHandler) HandleRoomTimeoutEvent(body json.RawMessage) {
 	)
 }
 
+// HandleRoomRemainTimeEvent는 커플 채팅방 만료 예정 알림을 처리합니다
+func (h *EventHandler) HandleRoomRemainTimeEvent(body json.RawMessage) {
+	var eventData eventtypes.RoomRemainTimeEvent
+	if err := json.Unmarshal(body, &eventData); err != nil {
+		log.Printf("❌ Failed to unmarshal room remain time event: %v", err)
+		return
+	}
+
+	// 게임방 남은 시간은 소켓으로만 알림
+	if eventData.Phase != commontype.RoomPhaseCouple {
+		return
+	}
+
+	alertEnabledUsers := h.filterAlertEnabledUsers(eventData.UserIDs)
+	if len(alertEnabledUsers) == 0 {
+		log.Printf("ℹ️ No alert-enabled users found for remain time event in room %s", eventData.RoomID)
+		return
+	}
+
+	remaining := time.Duration(eventData.RemainingSeconds) * time.Second
+	payload := createPushPayload(
+		alertEnabledUsers,
+		commontype.PushNotification{
+			Header:  "Couple Chat Ending Soon",
+			Content: fmt.Sprintf("Your couple chat ends in %s. Agree together to keep chatting!", remaining.Round(time.Minute)),
+			Url:     fmt.Sprintf("randomChat://game-room/%s", eventData.RoomID),
+		},
+	)
+
+	if err := onesignal.Push(payload); err != nil {
+		log.Printf("❌ Failed to send push notification: %v", err)
+		return
+	}
+
+	log.Printf("Couple room reminder push notification sent to %d users for room %s",
+		len(alertEnabledUsers),
+		eventData.RoomID,
+	)
+}
+
+// HandleCoupleRoomCloseEvent는 커플 채팅방 종료 알림을 처리합니다
+func (h *EventHandler) HandleCoupleRoomCloseEvent(body json.RawMessage) {
+	var eventData eventtypes.CoupleRoomCloseEvent
+	if err := json.Unmarshal(body, &eventData); err != nil {
+		log.Printf("❌ Failed to unmarshal couple room close event: %v", err)
+		return
+	}
+
+	alertEnabledUsers := h.filterAlertEnabledUsers(eventData.UserIDs)
+	if len(alertEnabledUsers) == 0 {
+		log.Printf("ℹ️ No alert-enabled users found for couple room close event in room %s", eventData.RoomID)
+		return
+	}
+
+	payload := createPushPayload(
+		alertEnabledUsers,
+		commontype.PushNotification{
+			Header:  "Couple Chat Closed",
+			Content: "Your couple chat has ended.",
+			Url:     fmt.Sprintf("randomChat://game-room/%s", eventData.RoomID),
+		},
+	)
+
+	if err := onesignal.Push(payload); err != nil {
+		log.Printf("❌ Failed to send push notification: %v", err)
+		return
+	}
+
+	log.Printf("Couple room close push notification sent to %d users for room %s",
+		len(alertEnabledUsers),
+		eventData.RoomID,
+	)
+}
+
 // filterAlertEnabledUsers는 알림 설정이 활성화된 사용자만 필터링
 func (h *EventHandler) filterAlertEnabledUsers(userIDs []int) []int {
 	return lo.Filter(userIDs, func(userID int, _ int) bool {
@@ -8,6 +8,9 @@ import (
 	"gorm.io/gorm"
 )
 
+// ErrNotEnoughGamePoint - 게임 포인트 잔액 부족
+var ErrNotEnoughGamePoint = errors.New("not enough game point")
+
 type UserRepository struct {
 	db *gorm.DB
 }
@@ -113,6 +116,38 @@ func (r *UserRepository) UpdateUserGameInfo(userID int, newStatus int, gameRoomI
 	return nil
 }
 
+// 게임 포인트 차감 (잔액이 부족하면 에러)
+func (r *UserRepository) SpendGamePoint(userID int, amount int) error {
+	result := r.db.Model(&models.User{}).
+		Where("id = ? AND game_point >= ?", userID, amount).
+		UpdateColumn("game_point", gorm.Expr("game_point - ?", amount))
+
+	if result.Error != nil {
+		log.Printf("❌ Failed to spend game point for user ID %d: %v", userID, result.Error)
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return ErrNotEnoughGamePoint
+	}
+
+	return nil
+}
+
+// 게임 포인트 환불 (차감 후 처리에 실패한 경우)
+func (r *UserRepository) RefundGamePoint(userID int, amount int) error {
+	result := r.db.Model(&models.User{}).
+		Where("id = ?", userID).
+		UpdateColumn("game_point", gorm.Expr("game_point + ?", amount))
+
+	if result.Error != nil {
+		log.Printf("❌ Failed to refund game point for user ID %d: %v", userID, result.Error)
+		return result.Error
+	}
+
+	return nil
+}
+
 // 유저 삭제
 func (r *UserRepository) DeleteUser(id int) error {
 	if err := r.db.Delete(&models.User{}, id).Error; err != nil {